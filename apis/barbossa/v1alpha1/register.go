@@ -30,6 +30,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&HighAvailabilityPolicy{},
 		&HighAvailabilityPolicyList{},
+		&ClusterHighAvailabilityPolicy{},
+		&ClusterHighAvailabilityPolicyList{},
+		&HighAvailabilityPolicyException{},
+		&HighAvailabilityPolicyExceptionList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
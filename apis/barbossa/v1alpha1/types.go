@@ -1,8 +1,8 @@
 package v1alpha1
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/api/core/v1"
-	"k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -15,6 +15,19 @@ type HighAvailabilityPolicySpec struct {
 	// with the highest weight will be used to validate that resource.
 	Weight int `json:"weight"`
 
+	// EnforcementAction determines what happens when a selected resource
+	// violates this Policy. When it's not set, resources are denied.
+	EnforcementAction EnforcementAction `json:"enforcementAction,omitempty"`
+
+	// Composition determines how this Policy is combined with the other
+	// policies which select the same resource when it takes precedence over
+	// them. When it's not set, only this Policy is used.
+	Composition Composition `json:"composition,omitempty"`
+
+	// UpdatePolicy determines which violations are enforced when a selected
+	// resource is updated. When it's not set, all violations are enforced.
+	UpdatePolicy UpdatePolicy `json:"updatePolicy,omitempty"`
+
 	// Selector is a LabelSelector to select a set of Deployments which fall
 	// under this Policy for validation.
 	Selector *metav1.LabelSelector `json:"selector"`
@@ -30,15 +43,245 @@ type HighAvailabilityPolicySpec struct {
 	// ResourceRequirements allow us to specify the types of resources should be
 	// configured for a Deployment and it's containers.
 	Resources *HighAvailabilityPolicyResourceRequirements `json:"resources,omitempty"`
+
+	// Disruptions allows us to configure how the selected Deployments should
+	// be covered by PodDisruptionBudgets.
+	Disruptions *HighAvailabilityPolicyDisruptions `json:"disruptions,omitempty"`
+
+	// StatefulSet allows us to configure the StatefulSet specific boundaries
+	// for the selected StatefulSets.
+	StatefulSet *HighAvailabilityPolicyStatefulSet `json:"statefulSet,omitempty"`
+
+	// DaemonSet allows us to configure the DaemonSet specific boundaries for
+	// the selected DaemonSets. The Replica Configuration doesn't apply to
+	// DaemonSets.
+	DaemonSet *HighAvailabilityPolicyDaemonSet `json:"daemonSet,omitempty"`
+
+	// Scheduling allows us to configure how the pods of the selected
+	// Deployments and StatefulSets should be spread across the cluster.
+	Scheduling *HighAvailabilityPolicyScheduling `json:"scheduling,omitempty"`
+
+	// Probes allows us to configure which probes the containers of the
+	// selected workloads should have configured.
+	Probes *HighAvailabilityPolicyProbes `json:"probes,omitempty"`
+
+	// Shutdown allows us to configure how the pods of the selected workloads
+	// should shut down so they don't drop connections.
+	Shutdown *HighAvailabilityPolicyShutdown `json:"shutdown,omitempty"`
+}
+
+// EnforcementAction determines how violations of a HighAvailabilityPolicy are
+// handled.
+type EnforcementAction string
+
+const (
+	// EnforcementActionDeny rejects resources which violate the policy.
+	EnforcementActionDeny EnforcementAction = "deny"
+
+	// EnforcementActionWarn allows resources which violate the policy. The
+	// violations are logged and returned in the message of the admission
+	// response.
+	EnforcementActionWarn EnforcementAction = "warn"
+
+	// EnforcementActionDryRun allows resources which violate the policy. The
+	// violations are only logged.
+	EnforcementActionDryRun EnforcementAction = "dryrun"
+)
+
+// Composition determines how a HighAvailabilityPolicy is combined with the
+// other policies which select the same resource.
+type Composition string
+
+const (
+	// CompositionOverride only uses the Policy which takes precedence, the
+	// other policies are ignored.
+	CompositionOverride Composition = "override"
+
+	// CompositionMerge combines the sections of all the policies which select
+	// the resource. When multiple policies configure the same section, the
+	// section of the Policy which takes precedence is used.
+	CompositionMerge Composition = "merge"
+)
+
+// UpdatePolicy determines which violations of a HighAvailabilityPolicy are
+// enforced when a resource is updated.
+type UpdatePolicy string
+
+const (
+	// UpdatePolicyStrict enforces all violations on updates, like it does
+	// when a resource is created.
+	UpdatePolicyStrict UpdatePolicy = "strict"
+
+	// UpdatePolicyNoNewViolations only enforces the violations the resource
+	// didn't have before the update. This allows updating resources which
+	// violate a policy which has been tightened, as long as the update
+	// doesn't make them violate it any further.
+	UpdatePolicyNoNewViolations UpdatePolicy = "noNewViolations"
+)
+
+// Section is the name of a section of a HighAvailabilityPolicy, it matches
+// the JSON name of the section in the spec.
+type Section string
+
+const (
+	SectionReplicas    Section = "replicas"
+	SectionStrategy    Section = "strategy"
+	SectionResources   Section = "resources"
+	SectionDisruptions Section = "disruptions"
+	SectionStatefulSet Section = "statefulSet"
+	SectionDaemonSet   Section = "daemonSet"
+	SectionScheduling  Section = "scheduling"
+	SectionProbes      Section = "probes"
+	SectionShutdown    Section = "shutdown"
+)
+
+// Sections lists all the sections of a HighAvailabilityPolicy.
+var Sections = []Section{
+	SectionReplicas,
+	SectionStrategy,
+	SectionResources,
+	SectionDisruptions,
+	SectionStatefulSet,
+	SectionDaemonSet,
+	SectionScheduling,
+	SectionProbes,
+	SectionShutdown,
+}
+
+const (
+	// ExemptAnnotation is the annotation which exempts a workload from a
+	// comma separated list of sections of the policy it's validated against.
+	ExemptAnnotation = "barbossa.sphc.io/exempt"
+
+	// ExemptionJustificationAnnotation is the annotation which explains why a
+	// workload is exempted. It's required for the exemption to apply.
+	ExemptionJustificationAnnotation = "barbossa.sphc.io/exemption-justification"
+
+	// ExemptionExpiresAnnotation is the annotation which holds the RFC3339
+	// timestamp after which the exemption no longer applies. When it's not
+	// set, the exemption doesn't expire.
+	ExemptionExpiresAnnotation = "barbossa.sphc.io/exemption-expires"
+)
+
+// HighAvailabilityPolicyDisruptions is the configuration to validate the
+// PodDisruptionBudgets which target a Deployment.
+type HighAvailabilityPolicyDisruptions struct {
+	// Budgetted enforces that the selected Deployments are targeted by a
+	// PodDisruptionBudget.
+	Budgetted bool `json:"budgetted"`
+
+	// Unique enforces that a PodDisruptionBudget only targets a single
+	// Deployment and that a Deployment is only targeted by a single
+	// PodDisruptionBudget.
+	Unique bool `json:"unique"`
+}
+
+// HighAvailabilityPolicyScheduling is the configuration to validate how the
+// pods of a workload are scheduled. A DaemonSet runs a pod per node, so this
+// configuration doesn't apply to DaemonSets.
+type HighAvailabilityPolicyScheduling struct {
+	// PodAntiAffinity enforces that the pods of the selected workloads are
+	// spread over the configured topology through pod anti-affinity.
+	PodAntiAffinity *HighAvailabilityPolicyPodAntiAffinity `json:"podAntiAffinity,omitempty"`
+}
+
+// PodAntiAffinityType determines how strict a pod anti-affinity term should
+// be.
+type PodAntiAffinityType string
+
+const (
+	// PodAntiAffinityRequired only allows terms which are required during
+	// scheduling.
+	PodAntiAffinityRequired PodAntiAffinityType = "required"
+
+	// PodAntiAffinityPreferred allows terms which are either required or
+	// preferred during scheduling.
+	PodAntiAffinityPreferred PodAntiAffinityType = "preferred"
+)
+
+// HighAvailabilityPolicyPodAntiAffinity is the configuration to validate the
+// pod anti-affinity of a pod template.
+type HighAvailabilityPolicyPodAntiAffinity struct {
+	// TopologyKey is the topology key the pods should be spread over, for
+	// example `kubernetes.io/hostname` or
+	// `failure-domain.beta.kubernetes.io/zone`.
+	TopologyKey string `json:"topologyKey"`
+
+	// Type determines if the anti-affinity term should be required or if a
+	// preferred term is sufficient. When it's not set, a preferred term is
+	// sufficient.
+	Type PodAntiAffinityType `json:"type,omitempty"`
+}
+
+// HighAvailabilityPolicyProbes is the configuration to validate the probes of
+// the containers of a workload. Configuring a probe makes it required.
+// Startup probes aren't available in the Kubernetes version we support.
+type HighAvailabilityPolicyProbes struct {
+	// Containers is a pattern which matches the names of the containers which
+	// should have probes configured, see `path.Match` for the syntax. When
+	// it's not set, all containers should have probes configured.
+	Containers string `json:"containers,omitempty"`
+
+	// Readiness is the configuration for the readiness probe of a container.
+	Readiness *HighAvailabilityPolicyProbe `json:"readiness,omitempty"`
+
+	// Liveness is the configuration for the liveness probe of a container.
+	Liveness *HighAvailabilityPolicyProbe `json:"liveness,omitempty"`
+}
+
+// HighAvailabilityPolicyProbe is the configuration to validate a single probe
+// of a container. Values which aren't set on the probe are validated against
+// the defaults of the API server.
+type HighAvailabilityPolicyProbe struct {
+	InitialDelaySeconds *Bounds `json:"initialDelaySeconds,omitempty"`
+	PeriodSeconds       *Bounds `json:"periodSeconds,omitempty"`
+	FailureThreshold    *Bounds `json:"failureThreshold,omitempty"`
+}
+
+// HighAvailabilityPolicyShutdown is the configuration to validate how the pods
+// of a workload shut down.
+type HighAvailabilityPolicyShutdown struct {
+	// PreStop enforces that containers which serve traffic, containers which
+	// expose a port, have a preStop handler configured. This allows them to
+	// drain their connections before they're stopped.
+	PreStop bool `json:"preStop,omitempty"`
+
+	// TerminationGracePeriodSeconds is the range the termination grace period
+	// of the pods should fall in.
+	TerminationGracePeriodSeconds *Bounds `json:"terminationGracePeriodSeconds,omitempty"`
+}
+
+// Bounds is the range a value should fall in. A bound which isn't set isn't
+// enforced.
+type Bounds struct {
+	Minimum *int32 `json:"minimum,omitempty"`
+	Maximum *int32 `json:"maximum,omitempty"`
 }
 
 // HighAvailabilityPolicyResourceRequirements is a validation rule that ensures
-// that certain values are set.
-// This will not validate they have minimum or maximum values, this should be
-// configured through a LimitRange (https://kubernetes.io/docs/tasks/administer-cluster/manage-resources/memory-default-namespace/)
+// that certain values are set and that they fall within the configured
+// minimum and maximum values.
+// Defaulting these values should be configured through a LimitRange
+// (https://kubernetes.io/docs/tasks/administer-cluster/manage-resources/memory-default-namespace/)
 type HighAvailabilityPolicyResourceRequirements struct {
 	Requests ResourceList `json:"requests"`
 	Limits   ResourceList `json:"limits"`
+
+	// MinRequests is the minimum quantity a container should request for a
+	// resource. Configuring a minimum makes the request required.
+	MinRequests v1.ResourceList `json:"minRequests,omitempty"`
+
+	// MaxRequests is the maximum quantity a container can request for a
+	// resource.
+	MaxRequests v1.ResourceList `json:"maxRequests,omitempty"`
+
+	// MinLimits is the minimum quantity a container should have as a limit
+	// for a resource. Configuring a minimum makes the limit required.
+	MinLimits v1.ResourceList `json:"minLimits,omitempty"`
+
+	// MaxLimits is the maximum quantity a container can have as a limit for a
+	// resource.
+	MaxLimits v1.ResourceList `json:"maxLimits,omitempty"`
 }
 
 // ResourceList represents a map of possible container resources and if they
@@ -46,11 +289,16 @@ type HighAvailabilityPolicyResourceRequirements struct {
 type ResourceList map[v1.ResourceName]bool
 
 // HighAvailabilityPolicyReplicas is the configuration to validate the Replica
-// count of a Deployment configuration.
+// count of a Deployment configuration. When a HorizontalPodAutoscaler manages
+// the Replica count, its minimum and maximum Replicas are validated instead.
 type HighAvailabilityPolicyReplicas struct {
 	// Minimum defines the minimum of Replicas we want our Deployments to have
 	// configured.
 	Minimum int32 `json:"minimum"`
+
+	// Maximum defines the maximum of Replicas we allow our Deployments to
+	// have configured. When it's not set, there is no maximum.
+	Maximum *int32 `json:"maximum,omitempty"`
 }
 
 // HighAvailabilityPolicyStrategy is the configuration to validate the
@@ -58,11 +306,25 @@ type HighAvailabilityPolicyReplicas struct {
 type HighAvailabilityPolicyStrategy struct {
 	// Type of Deployment. The selected deployments must be of this type to pass
 	// the validation.
-	Type v1beta1.DeploymentStrategyType `json:"type"`
+	Type appsv1.DeploymentStrategyType `json:"type"`
 
 	// Rolling Update configuration parameters. If the Type is RollingUpdate,
 	// this will be used to validate the linked RollingUpdate configuration.
 	RollingUpdate *HighAvailabilityPolicyRollingUpdate `json:"rollingUpdate,omitempty"`
+
+	// MinReadySeconds is the range the `minReadySeconds` of the selected
+	// Deployments should fall in. This is the time a new pod should be ready
+	// before it's considered available.
+	MinReadySeconds *Bounds `json:"minReadySeconds,omitempty"`
+
+	// ProgressDeadlineSeconds is the range the `progressDeadlineSeconds` of
+	// the selected Deployments should fall in.
+	ProgressDeadlineSeconds *Bounds `json:"progressDeadlineSeconds,omitempty"`
+
+	// RevisionHistoryLimit is the range the `revisionHistoryLimit` of the
+	// selected Deployments should fall in. A minimum makes sure there are
+	// revisions to roll back to.
+	RevisionHistoryLimit *Bounds `json:"revisionHistoryLimit,omitempty"`
 }
 
 // HighAvailabilityPolicyRollingUpdate is the configuration to validate the
@@ -82,6 +344,117 @@ type HighAvailabilityPolicyRollingUpdate struct {
 	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable"`
 }
 
+// HighAvailabilityPolicyStatefulSet is the configuration to validate the
+// StatefulSet specific configuration of a StatefulSet Resource.
+type HighAvailabilityPolicyStatefulSet struct {
+	// The UpdateStrategy allows us to configure specific boundaries in which
+	// the UpdateStrategy for the selected StatefulSets should fall.
+	UpdateStrategy *HighAvailabilityPolicyStatefulSetUpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// PodManagementPolicy is the policy the selected StatefulSets should use
+	// to manage their pods. When this isn't set, any policy is allowed.
+	PodManagementPolicy appsv1.PodManagementPolicyType `json:"podManagementPolicy,omitempty"`
+}
+
+// HighAvailabilityPolicyStatefulSetUpdateStrategy is the configuration to
+// validate the UpdateStrategy of a StatefulSet Resource.
+type HighAvailabilityPolicyStatefulSetUpdateStrategy struct {
+	// Type of StatefulSet UpdateStrategy. The selected StatefulSets must be of
+	// this type to pass the validation.
+	Type appsv1.StatefulSetUpdateStrategyType `json:"type"`
+
+	// Rolling Update configuration parameters. If the Type is RollingUpdate,
+	// this will be used to validate the linked RollingUpdate configuration.
+	RollingUpdate *HighAvailabilityPolicyStatefulSetRollingUpdate `json:"rollingUpdate,omitempty"`
+}
+
+// HighAvailabilityPolicyStatefulSetRollingUpdate is the configuration to
+// validate the RollingUpdate Strategy of a StatefulSet Resource.
+type HighAvailabilityPolicyStatefulSetRollingUpdate struct {
+	// MaxPartition is used to enforce that the `partition` on the StatefulSet
+	// Resource is at most this value. Pods with an ordinal lower than the
+	// partition are not updated during a rollout.
+	MaxPartition *int32 `json:"maxPartition,omitempty"`
+}
+
+// HighAvailabilityPolicyDaemonSet is the configuration to validate the
+// DaemonSet specific configuration of a DaemonSet Resource.
+type HighAvailabilityPolicyDaemonSet struct {
+	// The UpdateStrategy allows us to configure specific boundaries in which
+	// the UpdateStrategy for the selected DaemonSets should fall.
+	UpdateStrategy *HighAvailabilityPolicyDaemonSetUpdateStrategy `json:"updateStrategy,omitempty"`
+}
+
+// HighAvailabilityPolicyDaemonSetUpdateStrategy is the configuration to
+// validate the UpdateStrategy of a DaemonSet Resource.
+type HighAvailabilityPolicyDaemonSetUpdateStrategy struct {
+	// Type of DaemonSet UpdateStrategy. The selected DaemonSets must be of
+	// this type to pass the validation.
+	Type appsv1.DaemonSetUpdateStrategyType `json:"type"`
+
+	// Rolling Update configuration parameters. If the Type is RollingUpdate,
+	// this will be used to validate the linked RollingUpdate configuration.
+	RollingUpdate *HighAvailabilityPolicyDaemonSetRollingUpdate `json:"rollingUpdate,omitempty"`
+}
+
+// HighAvailabilityPolicyDaemonSetRollingUpdate is the configuration to
+// validate the RollingUpdate Strategy of a DaemonSet Resource. DaemonSets
+// don't support surging pods in this API version, so there's no surge
+// configuration.
+type HighAvailabilityPolicyDaemonSetRollingUpdate struct {
+	// MaxUnavailable is used to make sure the maxUnavailable configuration
+	// which determines the maximum number of pods can be unavailable during a
+	// rollout does not exceed this value.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// MaxStatusViolations is the maximum number of violating workloads which are
+// listed in the status of a policy.
+const MaxStatusViolations = 10
+
+// HighAvailabilityPolicyStatus describes the workloads a policy applies to and
+// whether they comply with it. A workload only counts towards the policy it's
+// validated against, which is the policy with the highest weight selecting it.
+type HighAvailabilityPolicyStatus struct {
+	// ObservedGeneration is the generation of the policy the status was
+	// calculated for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the current state of the policy.
+	Conditions []HighAvailabilityPolicyCondition `json:"conditions,omitempty"`
+
+	// MatchedWorkloads is the number of workloads which are validated against
+	// this policy.
+	MatchedWorkloads int32 `json:"matchedWorkloads"`
+
+	// ViolatingWorkloads is the number of matched workloads which violate this
+	// policy.
+	ViolatingWorkloads int32 `json:"violatingWorkloads"`
+
+	// Violations references the violating workloads, it lists at most
+	// MaxStatusViolations workloads.
+	Violations []v1.ObjectReference `json:"violations,omitempty"`
+}
+
+// HighAvailabilityPolicyConditionType is the type of a condition of a policy.
+type HighAvailabilityPolicyConditionType string
+
+const (
+	// HighAvailabilityPolicyCompliant is true when none of the matched
+	// workloads violate the policy.
+	HighAvailabilityPolicyCompliant HighAvailabilityPolicyConditionType = "Compliant"
+)
+
+// HighAvailabilityPolicyCondition describes the state of a policy at a point in
+// time.
+type HighAvailabilityPolicyCondition struct {
+	Type               HighAvailabilityPolicyConditionType `json:"type"`
+	Status             v1.ConditionStatus                  `json:"status"`
+	LastTransitionTime metav1.Time                         `json:"lastTransitionTime,omitempty"`
+	Reason             string                              `json:"reason,omitempty"`
+	Message            string                              `json:"message,omitempty"`
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -92,7 +465,8 @@ type HighAvailabilityPolicy struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec HighAvailabilityPolicySpec `json:"spec"`
+	Spec   HighAvailabilityPolicySpec   `json:"spec"`
+	Status HighAvailabilityPolicyStatus `json:"status,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -104,3 +478,100 @@ type HighAvailabilityPolicyList struct {
 	metav1.ListMeta `json:"metadata"`
 	Items           []HighAvailabilityPolicy `json:"items"`
 }
+
+// ClusterHighAvailabilityPolicySpec defines the configuration of a
+// ClusterHighAvailabilityPolicy. It contains the same configuration as a
+// HighAvailabilityPolicy and allows us to limit the namespaces it applies to.
+type ClusterHighAvailabilityPolicySpec struct {
+	HighAvailabilityPolicySpec `json:",inline"`
+
+	// NamespaceSelector is a LabelSelector to select the namespaces this
+	// Policy applies to. When it's not set, the Policy applies to all
+	// namespaces.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterHighAvailabilityPolicy is a HighAvailabilityPolicy which applies to
+// resources across namespaces. This allows us to configure a policy once
+// instead of in every namespace.
+type ClusterHighAvailabilityPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterHighAvailabilityPolicySpec `json:"spec"`
+	Status HighAvailabilityPolicyStatus      `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterHighAvailabilityPolicyList is a list of
+// ClusterHighAvailabilityPolicies which are available in the cluster.
+type ClusterHighAvailabilityPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ClusterHighAvailabilityPolicy `json:"items"`
+}
+
+// HighAvailabilityPolicyReference references the policy a
+// HighAvailabilityPolicyException applies to. A HighAvailabilityPolicy is
+// looked up in the namespace of the exception.
+type HighAvailabilityPolicyReference struct {
+	// Kind is either HighAvailabilityPolicy or ClusterHighAvailabilityPolicy.
+	// When it's not set, it defaults to HighAvailabilityPolicy.
+	Kind string `json:"kind,omitempty"`
+
+	// Name is the name of the policy.
+	Name string `json:"name"`
+}
+
+// HighAvailabilityPolicyExceptionSpec defines the sections of a policy the
+// selected workloads are exempted from.
+type HighAvailabilityPolicyExceptionSpec struct {
+	// Policy is the policy the selected workloads are exempted from.
+	Policy HighAvailabilityPolicyReference `json:"policy"`
+
+	// Selector is a LabelSelector to select the workloads in the namespace
+	// of the exception which are exempted. When it's not set, no workloads
+	// are exempted.
+	Selector *metav1.LabelSelector `json:"selector"`
+
+	// Sections are the sections of the policy the workloads are exempted
+	// from.
+	Sections []Section `json:"sections"`
+
+	// Expires is the time after which the exception no longer applies. When
+	// it's not set, the exception doesn't expire.
+	Expires *metav1.Time `json:"expires,omitempty"`
+
+	// Reason explains why the workloads are exempted.
+	Reason string `json:"reason"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HighAvailabilityPolicyException exempts the workloads it selects from
+// sections of a policy. Unlike the exemption annotations, which can be set by
+// anyone who can update the workload, granting an exception can be limited
+// to the people who should approve it through RBAC.
+type HighAvailabilityPolicyException struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec HighAvailabilityPolicyExceptionSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HighAvailabilityPolicyExceptionList is a list of
+// HighAvailabilityPolicyExceptions which are available in the cluster.
+type HighAvailabilityPolicyExceptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []HighAvailabilityPolicyException `json:"items"`
+}
@@ -0,0 +1,38 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateHorizontalPodAutoscaler validates the replica bounds of a
+// HorizontalPodAutoscaler against the replica configuration of the
+// HighAvailabilityPolicy which selects the workload it scales. The autoscaler
+// should never scale the workload outside of the bounds of the policy.
+func ValidateHorizontalPodAutoscaler(hpa autoscalingv1.HorizontalPodAutoscaler, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+	el := field.ErrorList{}
+
+	if hap.Spec.Replicas == nil {
+		return el
+	}
+
+	// the API server defaults the minimum number of replicas to 1.
+	minReplicas := int32(1)
+	if hpa.Spec.MinReplicas != nil {
+		minReplicas = *hpa.Spec.MinReplicas
+	}
+
+	if minReplicas < hap.Spec.Replicas.Minimum {
+		el = append(el, field.Invalid(specPath.Child("minReplicas"), minReplicas, fmt.Sprintf("should be at least %d", hap.Spec.Replicas.Minimum)))
+	}
+
+	if maximum := hap.Spec.Replicas.Maximum; maximum != nil && hpa.Spec.MaxReplicas > *maximum {
+		el = append(el, field.Invalid(specPath.Child("maxReplicas"), hpa.Spec.MaxReplicas, fmt.Sprintf("should be at most %d", *maximum)))
+	}
+
+	return el
+}
@@ -0,0 +1,95 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1/validation"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestHorizontalPodAutoscalerValidation(t *testing.T) {
+	hap := v1alpha1.HighAvailabilityPolicy{
+		Spec: v1alpha1.HighAvailabilityPolicySpec{
+			Replicas: &v1alpha1.HighAvailabilityPolicyReplicas{
+				Minimum: 2,
+				Maximum: ptrInt32(10),
+			},
+		},
+	}
+
+	tcs := map[string]struct {
+		hpa  autoscalingv1.HorizontalPodAutoscalerSpec
+		errs []*field.Error
+	}{
+		"with valid bounds": {
+			hpa: autoscalingv1.HorizontalPodAutoscalerSpec{
+				MinReplicas: ptrInt32(2),
+				MaxReplicas: 10,
+			},
+		},
+		"without a minimum": {
+			hpa: autoscalingv1.HorizontalPodAutoscalerSpec{
+				MaxReplicas: 5,
+			},
+			errs: []*field.Error{
+				field.Invalid(field.NewPath("spec").Child("minReplicas"), int32(1), "should be at least 2"),
+			},
+		},
+		"with bounds outside of the policy": {
+			hpa: autoscalingv1.HorizontalPodAutoscalerSpec{
+				MinReplicas: ptrInt32(1),
+				MaxReplicas: 20,
+			},
+			errs: []*field.Error{
+				field.Invalid(field.NewPath("spec").Child("minReplicas"), int32(1), "should be at least 2"),
+				field.Invalid(field.NewPath("spec").Child("maxReplicas"), int32(20), "should be at most 10"),
+			},
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			errs := validation.ValidateHorizontalPodAutoscaler(autoscalingv1.HorizontalPodAutoscaler{Spec: tc.hpa}, hap)
+			expectErrors(t, tc.errs, errs)
+		})
+	}
+}
+
+func TestAutoscaledDeploymentValidation(t *testing.T) {
+	hap := v1alpha1.HighAvailabilityPolicy{
+		Spec: v1alpha1.HighAvailabilityPolicySpec{
+			Replicas: &v1alpha1.HighAvailabilityPolicyReplicas{
+				Minimum: 2,
+			},
+		},
+	}
+
+	hpa := autoscalingv1.HorizontalPodAutoscaler{
+		Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+			MinReplicas: ptrInt32(2),
+			MaxReplicas: 10,
+		},
+	}
+	hpa.Name = "web"
+
+	t.Run("with an autoscaler within bounds", func(t *testing.T) {
+		// the replica count of the deployment is managed by the autoscaler,
+		// so it isn't validated.
+		dpl := appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: ptrInt32(1)}}
+		expectErrors(t, nil, validation.ValidateAutoscaledDeployment(dpl, hpa, hap))
+	})
+
+	t.Run("with an autoscaler outside of bounds", func(t *testing.T) {
+		hpa := *hpa.DeepCopy()
+		hpa.Spec.MinReplicas = ptrInt32(1)
+
+		dpl := appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: ptrInt32(3)}}
+		expectErrors(t, []*field.Error{
+			field.Invalid(field.NewPath("spec").Child("replicas"), int32(1), "HorizontalPodAutoscaler 'web' spec.minReplicas should be at least 2"),
+		}, validation.ValidateAutoscaledDeployment(dpl, hpa, hap))
+	})
+}
@@ -0,0 +1,89 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateDaemonSet validates the DaemonSet based on a HighAvailabilityPolicy
+// and ensures that all fields that are required are set correctly. A DaemonSet
+// runs a pod per node, so the replica configuration isn't validated.
+func ValidateDaemonSet(ds appsv1.DaemonSet, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+	el := field.ErrorList{}
+
+	el = validateDaemonSetUpdateStrategy(el, ds, hap)
+	el = validateResourceRequirements(el, ds.Spec.Template.Spec, hap)
+	el = validateProbes(el, ds.Spec.Template.Spec, hap)
+	el = validateShutdown(el, ds.Spec.Template.Spec, hap)
+
+	return el
+}
+
+func validateDaemonSetUpdateStrategy(el field.ErrorList, ds appsv1.DaemonSet, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+	if hap.Spec.DaemonSet == nil || hap.Spec.DaemonSet.UpdateStrategy == nil {
+		return el
+	}
+
+	path := specPath.Child("updateStrategy")
+	dsStrategy := ds.Spec.UpdateStrategy
+	hapStrategy := hap.Spec.DaemonSet.UpdateStrategy
+
+	if dsStrategy.Type != hapStrategy.Type {
+		return append(el, field.Invalid(path.Child("type"), string(dsStrategy.Type), fmt.Sprintf("should be '%s'", hapStrategy.Type)))
+	}
+
+	if dsStrategy.Type != appsv1.RollingUpdateDaemonSetStrategyType {
+		return el
+	}
+
+	if hapStrategy.RollingUpdate == nil || hapStrategy.RollingUpdate.MaxUnavailable == nil {
+		return el
+	}
+
+	// the API server defaults the maxUnavailable value to a single pod.
+	dsMaxUnavailable := intstr.FromInt(1)
+	if dsStrategy.RollingUpdate != nil && dsStrategy.RollingUpdate.MaxUnavailable != nil {
+		dsMaxUnavailable = *dsStrategy.RollingUpdate.MaxUnavailable
+	}
+
+	return validateDaemonSetMaxUnavailable(el, path.Child("rollingUpdate").Child("maxUnavailable"), ds, &dsMaxUnavailable, hapStrategy.RollingUpdate.MaxUnavailable)
+}
+
+// time to validate the maxUnavailable. It needs to be ds.maxUnavailable <= hap.maxUnavailable
+// A DaemonSet doesn't have a replica count to scale percentages against. Values
+// of the same type are compared directly, mixed values are scaled against the
+// number of nodes the DaemonSet should be scheduled on. When it isn't
+// scheduled on any nodes yet, like when it's created, no pods can become
+// unavailable and mixed values aren't compared. The audit controller
+// validates the DaemonSet again once it's scheduled.
+func validateDaemonSetMaxUnavailable(el field.ErrorList, path *field.Path, ds appsv1.DaemonSet, dsVal, hapVal *intstr.IntOrString) field.ErrorList {
+	total := 100
+	if dsVal.Type != hapVal.Type {
+		if ds.Status.DesiredNumberScheduled == 0 {
+			return el
+		}
+
+		total = int(ds.Status.DesiredNumberScheduled)
+	}
+
+	dsMax, err := intstr.GetValueFromIntOrPercent(dsVal, total, true)
+	if err != nil {
+		return append(el, field.Invalid(path, dsVal.String(), err.Error()))
+	}
+
+	hapMax, err := intstr.GetValueFromIntOrPercent(hapVal, total, true)
+	if err != nil {
+		return append(el, field.Invalid(path, hapVal.String(), err.Error()))
+	}
+
+	if dsMax > hapMax {
+		return append(el, field.Invalid(path, dsVal.String(), fmt.Sprintf("should be at most %s", hapVal.String())))
+	}
+
+	return el
+}
@@ -0,0 +1,129 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1/validation"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestDaemonSetValidation(t *testing.T) {
+	hap := v1alpha1.HighAvailabilityPolicy{
+		Spec: v1alpha1.HighAvailabilityPolicySpec{
+			Replicas: &v1alpha1.HighAvailabilityPolicyReplicas{
+				Minimum: 3,
+			},
+			DaemonSet: &v1alpha1.HighAvailabilityPolicyDaemonSet{
+				UpdateStrategy: &v1alpha1.HighAvailabilityPolicyDaemonSetUpdateStrategy{
+					Type: appsv1.RollingUpdateDaemonSetStrategyType,
+					RollingUpdate: &v1alpha1.HighAvailabilityPolicyDaemonSetRollingUpdate{
+						MaxUnavailable: fromIntStr("10%"),
+					},
+				},
+			},
+			Resources: &v1alpha1.HighAvailabilityPolicyResourceRequirements{
+				Limits: v1alpha1.ResourceList{
+					v1.ResourceMemory: true,
+				},
+			},
+		},
+	}
+
+	maxUnavailablePath := field.NewPath("spec").Child("updateStrategy").Child("rollingUpdate").Child("maxUnavailable")
+	rollingUpdate := func(maxUnavailable intstr.IntOrString) appsv1.DaemonSetUpdateStrategy {
+		return appsv1.DaemonSetUpdateStrategy{
+			Type: appsv1.RollingUpdateDaemonSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+				MaxUnavailable: &maxUnavailable,
+			},
+		}
+	}
+
+	tcs := map[string]struct {
+		strategy  appsv1.DaemonSetUpdateStrategy
+		scheduled int32
+		errs      []*field.Error
+	}{
+		"with a valid percentage": {
+			strategy: rollingUpdate(intstr.FromString("5%")),
+		},
+		"with a percentage too high": {
+			strategy: rollingUpdate(intstr.FromString("20%")),
+			errs: []*field.Error{
+				field.Invalid(maxUnavailablePath, "20%", "should be at most 10%"),
+			},
+		},
+		"with a number which isn't scheduled yet": {
+			strategy: rollingUpdate(intstr.FromInt(1)),
+		},
+		"with the default rolling update which isn't scheduled yet": {
+			strategy: appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.RollingUpdateDaemonSetStrategyType,
+			},
+		},
+		"with the default rolling update which is scheduled": {
+			strategy: appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.RollingUpdateDaemonSetStrategyType,
+			},
+			scheduled: 5,
+		},
+		"with a number within bounds of the scheduled pods": {
+			strategy:  rollingUpdate(intstr.FromInt(2)),
+			scheduled: 20,
+		},
+		"with a number too high for the scheduled pods": {
+			strategy:  rollingUpdate(intstr.FromInt(3)),
+			scheduled: 20,
+			errs: []*field.Error{
+				field.Invalid(maxUnavailablePath, "3", "should be at most 10%"),
+			},
+		},
+		"with a different update strategy type": {
+			strategy: appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.OnDeleteDaemonSetStrategyType,
+			},
+			errs: []*field.Error{
+				field.Invalid(field.NewPath("spec").Child("updateStrategy").Child("type"), string(appsv1.OnDeleteDaemonSetStrategyType), "should be 'RollingUpdate'"),
+			},
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			ds := appsv1.DaemonSet{
+				Spec: appsv1.DaemonSetSpec{
+					UpdateStrategy: tc.strategy,
+				},
+				Status: appsv1.DaemonSetStatus{
+					DesiredNumberScheduled: tc.scheduled,
+				},
+			}
+
+			errs := validation.ValidateDaemonSet(ds, hap)
+			expectErrors(t, tc.errs, errs)
+		})
+	}
+
+	t.Run("without resource limits", func(t *testing.T) {
+		ds := appsv1.DaemonSet{
+			Spec: appsv1.DaemonSetSpec{
+				UpdateStrategy: rollingUpdate(intstr.FromString("10%")),
+				Template: v1.PodTemplateSpec{
+					Spec: v1.PodSpec{
+						Containers: []v1.Container{{Name: "agent"}},
+					},
+				},
+			},
+		}
+
+		errs := validation.ValidateDaemonSet(ds, hap)
+		expectErrors(t, []*field.Error{
+			field.Invalid(field.NewPath("spec").Child("template").Child("spec").Child("containers").Index(0).Child("resources").Child("limits").Child("memory"), nil, "is required"),
+		}, errs)
+	})
+}
@@ -2,10 +2,18 @@ package validation
 
 import (
 	"fmt"
+	"path"
+	"sort"
+	"strings"
 
 	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
 
-	"k8s.io/api/extensions/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -14,33 +22,60 @@ var specPath = field.NewPath("spec")
 
 // ValidateDeployment validates the deployment based on a HighAvailabilityPolicy
 // and ensures that all fields that are required are set correctly.
-func ValidateDeployment(dpl v1beta1.Deployment, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+func ValidateDeployment(dpl appsv1.Deployment, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
 	el := field.ErrorList{}
 
-	el = validateReplicaCount(el, dpl, hap)
+	el = validateReplicaCount(el, dpl.Spec.Replicas, hap)
+	return validateDeploymentSpec(el, dpl, hap)
+}
+
+// ValidateAutoscaledDeployment validates a deployment of which the replica
+// count is managed by the given HorizontalPodAutoscaler. Instead of the replica
+// count of the deployment, the replica bounds of the autoscaler are validated.
+func ValidateAutoscaledDeployment(dpl appsv1.Deployment, hpa autoscalingv1.HorizontalPodAutoscaler, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+	el := field.ErrorList{}
+
+	for _, err := range ValidateHorizontalPodAutoscaler(hpa, hap) {
+		el = append(el, field.Invalid(specPath.Child("replicas"), err.BadValue, fmt.Sprintf("HorizontalPodAutoscaler '%s' %s %s", hpa.Name, err.Field, err.Detail)))
+	}
+
+	return validateDeploymentSpec(el, dpl, hap)
+}
+
+// validateDeploymentSpec validates everything but the replica count of a
+// deployment.
+func validateDeploymentSpec(el field.ErrorList, dpl appsv1.Deployment, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
 	el = validateUpdateStrategy(el, dpl, hap)
-	el = validateResourceRequirements(el, dpl, hap)
+	el = validateRollout(el, dpl, hap)
+	el = validateResourceRequirements(el, dpl.Spec.Template.Spec, hap)
+	el = validateProbes(el, dpl.Spec.Template.Spec, hap)
+	el = validateShutdown(el, dpl.Spec.Template.Spec, hap)
+	el = validatePodAntiAffinity(el, dpl.Namespace, dpl.Spec.Template, hap)
 
 	return el
 }
 
-func validateReplicaCount(el field.ErrorList, dpl v1beta1.Deployment, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+func validateReplicaCount(el field.ErrorList, replicas *int32, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
 	if hap.Spec.Replicas == nil {
 		return el
 	}
 
-	if dpl.Spec.Replicas == nil {
+	if replicas == nil {
 		return append(el, field.Invalid(specPath.Child("replicas"), nil, "is required"))
 	}
 
-	if *dpl.Spec.Replicas < hap.Spec.Replicas.Minimum {
-		return append(el, field.Invalid(specPath.Child("replicas"), dpl.Spec.Replicas, fmt.Sprintf("should be at least %d", hap.Spec.Replicas.Minimum)))
+	if *replicas < hap.Spec.Replicas.Minimum {
+		return append(el, field.Invalid(specPath.Child("replicas"), replicas, fmt.Sprintf("should be at least %d", hap.Spec.Replicas.Minimum)))
+	}
+
+	if maximum := hap.Spec.Replicas.Maximum; maximum != nil && *replicas > *maximum {
+		return append(el, field.Invalid(specPath.Child("replicas"), replicas, fmt.Sprintf("should be at most %d", *maximum)))
 	}
 
 	return el
 }
 
-func validateUpdateStrategy(el field.ErrorList, dpl v1beta1.Deployment, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+func validateUpdateStrategy(el field.ErrorList, dpl appsv1.Deployment, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
 	if hap.Spec.Strategy == nil {
 		return el
 	}
@@ -49,11 +84,19 @@ func validateUpdateStrategy(el field.ErrorList, dpl v1beta1.Deployment, hap v1al
 	dplStrategy := dpl.Spec.Strategy
 	hapStrategy := hap.Spec.Strategy
 
-	if dplStrategy.Type != hapStrategy.Type {
+	// a policy without a type doesn't require a specific strategy, it only
+	// bounds the rolling update values when these are configured.
+	if hapStrategy.Type != "" && dplStrategy.Type != hapStrategy.Type {
 		return append(el, field.Invalid(path.Child("type"), string(dplStrategy.Type), fmt.Sprintf("should be '%s'", hapStrategy.Type)))
 	}
 
-	if dplStrategy.Type == v1beta1.RollingUpdateDeploymentStrategyType {
+	// a policy without a rolling update configuration only bounds the
+	// rollout, see validateRollout.
+	if hapStrategy.RollingUpdate == nil {
+		return el
+	}
+
+	if dplStrategy.Type == appsv1.RollingUpdateDeploymentStrategyType {
 		upPath := path.Child("rollingUpdate")
 		if dplStrategy.RollingUpdate == nil {
 			return append(el, field.Invalid(upPath, nil, "is required"))
@@ -74,8 +117,40 @@ func validateUpdateStrategy(el field.ErrorList, dpl v1beta1.Deployment, hap v1al
 	return el
 }
 
+// validateRollout validates the values which determine how safe a rollout is
+// and how it can be rolled back. These are validated regardless of the
+// strategy type.
+func validateRollout(el field.ErrorList, dpl appsv1.Deployment, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+	if hap.Spec.Strategy == nil {
+		return el
+	}
+
+	el = validateBounds(el, specPath.Child("minReadySeconds"), int64(dpl.Spec.MinReadySeconds), hap.Spec.Strategy.MinReadySeconds)
+	el = validateUnboundedBounds(el, specPath.Child("progressDeadlineSeconds"), dpl.Spec.ProgressDeadlineSeconds, hap.Spec.Strategy.ProgressDeadlineSeconds)
+	el = validateUnboundedBounds(el, specPath.Child("revisionHistoryLimit"), dpl.Spec.RevisionHistoryLimit, hap.Spec.Strategy.RevisionHistoryLimit)
+
+	return el
+}
+
+// validateUnboundedBounds validates a value which has no limit when it's not
+// set. The API server defaults these values for the apps API groups, they're
+// only unset for the extensions/v1beta1 API version, which has no deadline
+// and keeps the full revision history by default. An unset value exceeds any
+// maximum.
+func validateUnboundedBounds(el field.ErrorList, path *field.Path, value *int32, bounds *v1alpha1.Bounds) field.ErrorList {
+	if value != nil {
+		return validateBounds(el, path, int64(*value), bounds)
+	}
+
+	if bounds != nil && bounds.Maximum != nil {
+		return append(el, field.Invalid(path, nil, fmt.Sprintf("should be at most %d", *bounds.Maximum)))
+	}
+
+	return el
+}
+
 // validate the maxSurge value. It needs to be hap.minSurge <= dpl.maxSurge <= hap.maxSurge
-func validateMaxSurge(el field.ErrorList, upPath *field.Path, reps int, dplStrategy v1beta1.DeploymentStrategy, hapStrategy *v1alpha1.HighAvailabilityPolicyStrategy) field.ErrorList {
+func validateMaxSurge(el field.ErrorList, upPath *field.Path, reps int, dplStrategy appsv1.DeploymentStrategy, hapStrategy *v1alpha1.HighAvailabilityPolicyStrategy) field.ErrorList {
 	dplVal, err := intstr.GetValueFromIntOrPercent(dplStrategy.RollingUpdate.MaxSurge, reps, true)
 	if err != nil {
 		return append(el, field.Invalid(upPath.Child("maxSurge"), dplStrategy.RollingUpdate.MaxSurge.String(), err.Error()))
@@ -108,7 +183,7 @@ func validateMaxSurge(el field.ErrorList, upPath *field.Path, reps int, dplStrat
 }
 
 // time to validate the maxUnavailable. It needs to be dpl.maxUnavailable <= hap.maxUnavailable
-func validateMaxUnavailable(el field.ErrorList, upPath *field.Path, reps int, dplStrategy v1beta1.DeploymentStrategy, hapStrategy *v1alpha1.HighAvailabilityPolicyStrategy) field.ErrorList {
+func validateMaxUnavailable(el field.ErrorList, upPath *field.Path, reps int, dplStrategy appsv1.DeploymentStrategy, hapStrategy *v1alpha1.HighAvailabilityPolicyStrategy) field.ErrorList {
 	if hapStrategy.RollingUpdate.MaxUnavailable == nil {
 		return el
 	}
@@ -129,38 +204,500 @@ func validateMaxUnavailable(el field.ErrorList, upPath *field.Path, reps int, dp
 	return el
 }
 
-func validateResourceRequirements(el field.ErrorList, dpl v1beta1.Deployment, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+func validateResourceRequirements(el field.ErrorList, podSpec v1.PodSpec, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
 	resources := hap.Spec.Resources
 	if resources == nil {
 		return el
 	}
 
+	requiredRequests := requiredResources(resources.Requests, resources.MinRequests)
+	requiredLimits := requiredResources(resources.Limits, resources.MinLimits)
+
 	cPath := specPath.Child("template").Child("spec").Child("containers")
-	for _, container := range dpl.Spec.Template.Spec.Containers {
-		path := cPath.Child(container.Name).Child("resources")
+	for i, container := range podSpec.Containers {
+		path := cPath.Index(i).Child("resources")
 
 		rPath := path.Child("requests")
-		for name, rc := range resources.Requests {
-			if !rc {
+		el = validateRequiredResources(el, rPath, container.Resources.Requests, requiredRequests)
+		el = validateQuantities(el, rPath, container.Resources.Requests, resources.MinRequests, resources.MaxRequests)
+
+		lPath := path.Child("limits")
+		el = validateRequiredResources(el, lPath, container.Resources.Limits, requiredLimits)
+		el = validateQuantities(el, lPath, container.Resources.Limits, resources.MinLimits, resources.MaxLimits)
+	}
+
+	return el
+}
+
+// requiredResources returns the sorted names of the resources which should be
+// configured. These are the resources which are marked as required and the
+// resources which have a minimum quantity configured.
+func requiredResources(required v1alpha1.ResourceList, min v1.ResourceList) []v1.ResourceName {
+	names := []v1.ResourceName{}
+	for name, rc := range required {
+		if rc {
+			names = append(names, name)
+		}
+	}
+
+	for name := range min {
+		if !required[name] {
+			names = append(names, name)
+		}
+	}
+
+	return sortResourceNames(names)
+}
+
+func validateRequiredResources(el field.ErrorList, path *field.Path, values v1.ResourceList, required []v1.ResourceName) field.ErrorList {
+	for _, name := range required {
+		if _, ok := values[name]; !ok {
+			el = append(el, field.Invalid(path.Child(string(name)), nil, "is required"))
+		}
+	}
+
+	return el
+}
+
+// validate the configured quantities. They need to be min <= value <= max.
+// Resources which aren't configured are validated as required resources.
+func validateQuantities(el field.ErrorList, path *field.Path, values, min, max v1.ResourceList) field.ErrorList {
+	for _, name := range sortedResourceNames(min) {
+		val, ok := values[name]
+		if !ok {
+			continue
+		}
+
+		minVal := min[name]
+		if val.Cmp(minVal) < 0 {
+			el = append(el, field.Invalid(path.Child(string(name)), val.String(), fmt.Sprintf("should be at least %s", minVal.String())))
+		}
+	}
+
+	for _, name := range sortedResourceNames(max) {
+		val, ok := values[name]
+		if !ok {
+			continue
+		}
+
+		maxVal := max[name]
+		if val.Cmp(maxVal) > 0 {
+			el = append(el, field.Invalid(path.Child(string(name)), val.String(), fmt.Sprintf("should be at most %s", maxVal.String())))
+		}
+	}
+
+	return el
+}
+
+// sortedResourceNames returns the names in the ResourceList in a stable order
+// so errors are always reported in the same order.
+func sortedResourceNames(rl v1.ResourceList) []v1.ResourceName {
+	names := make([]v1.ResourceName, 0, len(rl))
+	for name := range rl {
+		names = append(names, name)
+	}
+
+	return sortResourceNames(names)
+}
+
+func sortResourceNames(names []v1.ResourceName) []v1.ResourceName {
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+
+	return names
+}
+
+// The API server defaults these probe values when they're not set. An initial
+// delay which isn't set means the probe starts right away.
+const (
+	defaultProbePeriodSeconds    = 10
+	defaultProbeFailureThreshold = 3
+)
+
+func validateProbes(el field.ErrorList, podSpec v1.PodSpec, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+	probes := hap.Spec.Probes
+	if probes == nil {
+		return el
+	}
+
+	cPath := specPath.Child("template").Child("spec").Child("containers")
+	for i, container := range podSpec.Containers {
+		if probes.Containers != "" {
+			ok, err := path.Match(probes.Containers, container.Name)
+			if err != nil {
+				return append(el, field.Invalid(cPath, probes.Containers, fmt.Sprintf("can't be matched against the container pattern of the policy: %s", err)))
+			}
+
+			if !ok {
+				continue
+			}
+		}
+
+		el = validateProbe(el, cPath.Index(i).Child("readinessProbe"), container.ReadinessProbe, probes.Readiness)
+		el = validateProbe(el, cPath.Index(i).Child("livenessProbe"), container.LivenessProbe, probes.Liveness)
+	}
+
+	return el
+}
+
+func validateProbe(el field.ErrorList, pPath *field.Path, probe *v1.Probe, hapProbe *v1alpha1.HighAvailabilityPolicyProbe) field.ErrorList {
+	if hapProbe == nil {
+		return el
+	}
+
+	if probe == nil {
+		return append(el, field.Invalid(pPath, nil, "is required"))
+	}
+
+	periodSeconds := probe.PeriodSeconds
+	if periodSeconds == 0 {
+		periodSeconds = defaultProbePeriodSeconds
+	}
+
+	failureThreshold := probe.FailureThreshold
+	if failureThreshold == 0 {
+		failureThreshold = defaultProbeFailureThreshold
+	}
+
+	el = validateBounds(el, pPath.Child("initialDelaySeconds"), int64(probe.InitialDelaySeconds), hapProbe.InitialDelaySeconds)
+	el = validateBounds(el, pPath.Child("periodSeconds"), int64(periodSeconds), hapProbe.PeriodSeconds)
+	el = validateBounds(el, pPath.Child("failureThreshold"), int64(failureThreshold), hapProbe.FailureThreshold)
+
+	return el
+}
+
+// validate the value against the bounds. It needs to be min <= value <= max.
+func validateBounds(el field.ErrorList, path *field.Path, value int64, bounds *v1alpha1.Bounds) field.ErrorList {
+	if bounds == nil {
+		return el
+	}
+
+	if bounds.Minimum != nil && value < int64(*bounds.Minimum) {
+		return append(el, field.Invalid(path, value, fmt.Sprintf("should be at least %d", *bounds.Minimum)))
+	}
+
+	if bounds.Maximum != nil && value > int64(*bounds.Maximum) {
+		return append(el, field.Invalid(path, value, fmt.Sprintf("should be at most %d", *bounds.Maximum)))
+	}
+
+	return el
+}
+
+// The API server defaults the termination grace period of a pod when it's not
+// set.
+const defaultTerminationGracePeriodSeconds = 30
+
+func validateShutdown(el field.ErrorList, podSpec v1.PodSpec, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+	shutdown := hap.Spec.Shutdown
+	if shutdown == nil {
+		return el
+	}
+
+	sPath := specPath.Child("template").Child("spec")
+	if shutdown.PreStop {
+		cPath := sPath.Child("containers")
+		for i, container := range podSpec.Containers {
+			// containers without ports don't serve traffic, so there are no
+			// connections to drain.
+			if len(container.Ports) == 0 {
 				continue
 			}
 
-			if _, ok := container.Resources.Requests[name]; !ok {
-				el = append(el, field.Invalid(rPath.Child(string(name)), nil, "is required"))
+			if container.Lifecycle == nil || container.Lifecycle.PreStop == nil {
+				el = append(el, field.Invalid(cPath.Index(i).Child("lifecycle").Child("preStop"), nil, "is required"))
 			}
 		}
+	}
 
-		lPath := path.Child("limits")
-		for name, rc := range resources.Limits {
-			if !rc {
+	gracePeriod := int64(defaultTerminationGracePeriodSeconds)
+	if podSpec.TerminationGracePeriodSeconds != nil {
+		gracePeriod = *podSpec.TerminationGracePeriodSeconds
+	}
+
+	return validateBounds(el, sPath.Child("terminationGracePeriodSeconds"), gracePeriod, shutdown.TerminationGracePeriodSeconds)
+}
+
+// validatePodAntiAffinity validates that the pod template contains a pod
+// anti-affinity term for the configured topology key. The term should select
+// the pods of the template itself, otherwise it doesn't spread them.
+func validatePodAntiAffinity(el field.ErrorList, namespace string, template v1.PodTemplateSpec, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+	if hap.Spec.Scheduling == nil || hap.Spec.Scheduling.PodAntiAffinity == nil {
+		return el
+	}
+
+	hapAffinity := hap.Spec.Scheduling.PodAntiAffinity
+	path := specPath.Child("template").Child("spec").Child("affinity").Child("podAntiAffinity")
+
+	affinity := template.Spec.Affinity
+	if affinity == nil || affinity.PodAntiAffinity == nil {
+		return append(el, field.Invalid(path, nil, "is required"))
+	}
+
+	terms := []podAffinityTerm{}
+	rPath := path.Child("requiredDuringSchedulingIgnoredDuringExecution")
+	for i, term := range affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+		terms = append(terms, podAffinityTerm{path: rPath.Index(i), term: term})
+	}
+
+	if hapAffinity.Type != v1alpha1.PodAntiAffinityRequired {
+		pPath := path.Child("preferredDuringSchedulingIgnoredDuringExecution")
+		for i, term := range affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			terms = append(terms, podAffinityTerm{path: pPath.Index(i).Child("podAffinityTerm"), term: term.PodAffinityTerm})
+		}
+	}
+
+	// a single term which spreads the pods is sufficient, we only report the
+	// terms with the right topology key when none of them does.
+	termErrs := field.ErrorList{}
+	for _, t := range terms {
+		if t.term.TopologyKey != hapAffinity.TopologyKey {
+			continue
+		}
+
+		if len(t.term.Namespaces) > 0 && !containsString(t.term.Namespaces, namespace) {
+			termErrs = append(termErrs, field.Invalid(t.path.Child("namespaces"), t.term.Namespaces, fmt.Sprintf("should contain '%s'", namespace)))
+			continue
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(t.term.LabelSelector)
+		if err != nil {
+			termErrs = append(termErrs, field.Invalid(t.path.Child("labelSelector"), t.term.LabelSelector, err.Error()))
+			continue
+		}
+
+		if !selector.Matches(labels.Set(template.Labels)) {
+			termErrs = append(termErrs, field.Invalid(t.path.Child("labelSelector"), t.term.LabelSelector, "should select the pod template labels"))
+			continue
+		}
+
+		return el
+	}
+
+	if len(termErrs) > 0 {
+		return append(el, termErrs...)
+	}
+
+	if hapAffinity.Type == v1alpha1.PodAntiAffinityRequired {
+		path = rPath
+	}
+
+	return append(el, field.Invalid(path, nil, fmt.Sprintf("should contain a term with topologyKey '%s'", hapAffinity.TopologyKey)))
+}
+
+type podAffinityTerm struct {
+	path *field.Path
+	term v1.PodAffinityTerm
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateDisruptions validates that the deployment is covered by the
+// PodDisruptionBudgets in its namespace as configured in the
+// HighAvailabilityPolicy. The given list of deployments are the other
+// Deployments living in the same namespace, these are used to detect budgets
+// which select more than a single Deployment.
+func ValidateDisruptions(dpl appsv1.Deployment, pdbs []policyv1beta1.PodDisruptionBudget, dpls []appsv1.Deployment, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+	el := field.ErrorList{}
+
+	disruptions := hap.Spec.Disruptions
+	if disruptions == nil {
+		return el
+	}
+
+	// PodDisruptionBudgets select pods, so we match them against the labels
+	// of the pod template instead of the labels of the Deployment itself.
+	path := specPath.Child("template").Child("metadata").Child("labels")
+	podLabels := dpl.Spec.Template.Labels
+
+	selecting := []policyv1beta1.PodDisruptionBudget{}
+	for _, pdb := range pdbs {
+		ok, err := selectsLabels(pdb, podLabels)
+		if err != nil {
+			el = append(el, field.Invalid(path, podLabels, fmt.Sprintf("PodDisruptionBudget '%s' has an invalid selector: %s", pdb.Name, err)))
+			continue
+		}
+
+		if ok {
+			selecting = append(selecting, pdb)
+		}
+	}
+
+	if disruptions.Budgetted && len(selecting) == 0 {
+		el = append(el, field.Invalid(path, podLabels, "should be selected by a PodDisruptionBudget"))
+	}
+
+	for _, pdb := range selecting {
+		for _, err := range validateBudgetAllowance(field.ErrorList{}, pdb, deploymentTarget(dpl)) {
+			el = append(el, field.Invalid(path, podLabels, fmt.Sprintf("PodDisruptionBudget '%s' %s %s", pdb.Name, err.Field, err.Detail)))
+		}
+	}
+
+	if !disruptions.Unique {
+		return el
+	}
+
+	if len(selecting) > 1 {
+		names := make([]string, len(selecting))
+		for i, pdb := range selecting {
+			names[i] = pdb.Name
+		}
+
+		el = append(el, field.Invalid(path, podLabels, fmt.Sprintf("should be selected by a single PodDisruptionBudget, selected by '%s'", strings.Join(names, "', '"))))
+	}
+
+	for _, pdb := range selecting {
+		for _, other := range dpls {
+			// the list of deployments can contain the deployment we're
+			// validating when it's being updated, this shouldn't collide.
+			if other.Name == dpl.Name {
 				continue
 			}
 
-			if _, ok := container.Resources.Limits[name]; !ok {
-				el = append(el, field.Invalid(lPath.Child(string(name)), nil, "is required"))
+			// we've validated the selector above, no need to check the error.
+			if ok, _ := selectsLabels(pdb, other.Spec.Template.Labels); ok {
+				el = append(el, field.Invalid(path, podLabels, fmt.Sprintf("PodDisruptionBudget '%s' also selects Deployment '%s'", pdb.Name, other.Name)))
 			}
 		}
 	}
 
 	return el
 }
+
+// ValidateDisruptionBudget validates the PodDisruptionBudget against the
+// Deployment it selects, as configured in the HighAvailabilityPolicy which
+// selects that Deployment. A budget which doesn't allow any of the pods to be
+// disrupted blocks node drains, a budget which allows all of them to be
+// disrupted doesn't protect the Deployment. The given lists of budgets and
+// deployments are the other PodDisruptionBudgets and Deployments living in
+// the same namespace, these are used to detect budgets which overlap when
+// they should be unique.
+func ValidateDisruptionBudget(pdb policyv1beta1.PodDisruptionBudget, dpl appsv1.Deployment, pdbs []policyv1beta1.PodDisruptionBudget, dpls []appsv1.Deployment, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+	others := make([]budgetTarget, len(dpls))
+	for i, other := range dpls {
+		others[i] = deploymentTarget(other)
+	}
+
+	return validateDisruptionBudget(field.ErrorList{}, pdb, deploymentTarget(dpl), pdbs, others, hap)
+}
+
+// budgetTarget is a workload of which the pods are selected by a
+// PodDisruptionBudget.
+type budgetTarget struct {
+	kind      string
+	name      string
+	replicas  *int32
+	podLabels map[string]string
+}
+
+func deploymentTarget(dpl appsv1.Deployment) budgetTarget {
+	return budgetTarget{kind: "Deployment", name: dpl.Name, replicas: dpl.Spec.Replicas, podLabels: dpl.Spec.Template.Labels}
+}
+
+// validateDisruptionBudget validates the PodDisruptionBudget against the
+// workload it selects. The other budgets and workloads of the same kind are
+// used to detect budgets which overlap when they should be unique.
+func validateDisruptionBudget(el field.ErrorList, pdb policyv1beta1.PodDisruptionBudget, target budgetTarget, pdbs []policyv1beta1.PodDisruptionBudget, others []budgetTarget, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+	disruptions := hap.Spec.Disruptions
+	if disruptions == nil {
+		return el
+	}
+
+	el = validateBudgetAllowance(el, pdb, target)
+	if !disruptions.Unique {
+		return el
+	}
+
+	path := specPath.Child("selector")
+	selector := metav1.FormatLabelSelector(pdb.Spec.Selector)
+
+	for _, other := range pdbs {
+		// the list of budgets contains the budget we're validating when it's
+		// being updated, this shouldn't collide.
+		if other.Name == pdb.Name {
+			continue
+		}
+
+		if ok, err := selectsLabels(other, target.podLabels); err == nil && ok {
+			el = append(el, field.Invalid(path, selector, fmt.Sprintf("should be the only PodDisruptionBudget selecting %s '%s', also selected by '%s'", target.kind, target.name, other.Name)))
+		}
+	}
+
+	for _, other := range others {
+		if other.name == target.name {
+			continue
+		}
+
+		if ok, err := selectsLabels(pdb, other.podLabels); err == nil && ok {
+			el = append(el, field.Invalid(path, selector, fmt.Sprintf("should only select %s '%s', also selects %s '%s'", target.kind, target.name, other.kind, other.name)))
+		}
+	}
+
+	return el
+}
+
+// validateBudgetAllowance validates that the budget allows at least one, but
+// not all, of the pods of the workload to be disrupted. Percentages are
+// rounded up, the same way the disruption controller does.
+func validateBudgetAllowance(el field.ErrorList, pdb policyv1beta1.PodDisruptionBudget, target budgetTarget) field.ErrorList {
+	replicas := 1
+	if target.replicas != nil {
+		replicas = int(*target.replicas)
+	}
+
+	noDisruptions := fmt.Sprintf("should allow a pod of %s '%s' with %d replicas to be disrupted", target.kind, target.name, replicas)
+	allDisruptions := fmt.Sprintf("should keep a pod of %s '%s' with %d replicas available", target.kind, target.name, replicas)
+
+	if pdb.Spec.MinAvailable != nil {
+		path := specPath.Child("minAvailable")
+		minAvailable, err := intstr.GetValueFromIntOrPercent(pdb.Spec.MinAvailable, replicas, true)
+		if err != nil {
+			return append(el, field.Invalid(path, pdb.Spec.MinAvailable.String(), err.Error()))
+		}
+
+		if minAvailable >= replicas {
+			el = append(el, field.Invalid(path, pdb.Spec.MinAvailable.String(), noDisruptions))
+		} else if minAvailable <= 0 {
+			el = append(el, field.Invalid(path, pdb.Spec.MinAvailable.String(), allDisruptions))
+		}
+	}
+
+	if pdb.Spec.MaxUnavailable != nil {
+		path := specPath.Child("maxUnavailable")
+		maxUnavailable, err := intstr.GetValueFromIntOrPercent(pdb.Spec.MaxUnavailable, replicas, true)
+		if err != nil {
+			return append(el, field.Invalid(path, pdb.Spec.MaxUnavailable.String(), err.Error()))
+		}
+
+		if maxUnavailable <= 0 {
+			el = append(el, field.Invalid(path, pdb.Spec.MaxUnavailable.String(), noDisruptions))
+		} else if maxUnavailable >= replicas {
+			el = append(el, field.Invalid(path, pdb.Spec.MaxUnavailable.String(), allDisruptions))
+		}
+	}
+
+	return el
+}
+
+// selectsLabels checks if the PodDisruptionBudget selects pods with the given
+// labels. An empty selector on a PodDisruptionBudget selects no pods.
+func selectsLabels(pdb policyv1beta1.PodDisruptionBudget, lbls map[string]string) (bool, error) {
+	selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+	if err != nil {
+		return false, err
+	}
+
+	if selector.Empty() {
+		return false, nil
+	}
+
+	return selector.Matches(labels.Set(lbls)), nil
+}
@@ -8,9 +8,11 @@ import (
 	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
 	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1/validation"
 
+	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/api/core/v1"
-	"k8s.io/api/extensions/v1beta1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -21,18 +23,27 @@ func TestDeploymentValidation(t *testing.T) {
 			Spec: v1alpha1.HighAvailabilityPolicySpec{
 				Replicas: &v1alpha1.HighAvailabilityPolicyReplicas{
 					Minimum: 2,
+					Maximum: ptrInt32(10),
 				},
 			},
 		}
 
 		tcs := map[string]testCase{
 			"with a valid spec": {
-				dpl: v1beta1.DeploymentSpec{
+				dpl: appsv1.DeploymentSpec{
 					Replicas: ptrInt32(3),
 				},
 			},
+			"with a replica count too high": {
+				dpl: appsv1.DeploymentSpec{
+					Replicas: ptrInt32(20),
+				},
+				errs: []*field.Error{
+					field.Invalid(field.NewPath("spec").Child("replicas"), ptrInt32(20), "should be at most 10"),
+				},
+			},
 			"with an invalid replica count": {
-				dpl: v1beta1.DeploymentSpec{
+				dpl: appsv1.DeploymentSpec{
 					Replicas: ptrInt32(1),
 				},
 				errs: []*field.Error{
@@ -40,7 +51,7 @@ func TestDeploymentValidation(t *testing.T) {
 				},
 			},
 			"with no replica count set": {
-				dpl: v1beta1.DeploymentSpec{},
+				dpl: appsv1.DeploymentSpec{},
 				errs: []*field.Error{
 					field.Invalid(field.NewPath("spec").Child("replicas"), nil, "is required"),
 				},
@@ -54,7 +65,7 @@ func TestDeploymentValidation(t *testing.T) {
 		hap := v1alpha1.HighAvailabilityPolicy{
 			Spec: v1alpha1.HighAvailabilityPolicySpec{
 				Strategy: &v1alpha1.HighAvailabilityPolicyStrategy{
-					Type: v1beta1.RollingUpdateDeploymentStrategyType,
+					Type: appsv1.RollingUpdateDeploymentStrategyType,
 					RollingUpdate: &v1alpha1.HighAvailabilityPolicyRollingUpdate{
 						MinSurge:       fromIntStr("25%"),
 						MaxSurge:       fromIntStr("75%"),
@@ -66,11 +77,11 @@ func TestDeploymentValidation(t *testing.T) {
 
 		tcs := map[string]testCase{
 			"with a valid spec": {
-				dpl: v1beta1.DeploymentSpec{
+				dpl: appsv1.DeploymentSpec{
 					Replicas: ptrInt32(3),
-					Strategy: v1beta1.DeploymentStrategy{
-						Type: v1beta1.RollingUpdateDeploymentStrategyType,
-						RollingUpdate: &v1beta1.RollingUpdateDeployment{
+					Strategy: appsv1.DeploymentStrategy{
+						Type: appsv1.RollingUpdateDeploymentStrategyType,
+						RollingUpdate: &appsv1.RollingUpdateDeployment{
 							MaxSurge:       fromIntStr("50%"),
 							MaxUnavailable: fromIntStr("0"),
 						},
@@ -78,18 +89,18 @@ func TestDeploymentValidation(t *testing.T) {
 				},
 			},
 			"without a strategy defined": {
-				dpl: v1beta1.DeploymentSpec{
+				dpl: appsv1.DeploymentSpec{
 					Replicas: ptrInt32(3),
 				},
 				errs: []*field.Error{
-					field.Invalid(field.NewPath("spec").Child("strategy").Child("type"), "", fmt.Sprintf("should be '%s'", v1beta1.RollingUpdateDeploymentStrategyType)),
+					field.Invalid(field.NewPath("spec").Child("strategy").Child("type"), "", fmt.Sprintf("should be '%s'", appsv1.RollingUpdateDeploymentStrategyType)),
 				},
 			},
 			"without a rolling update configuration": {
-				dpl: v1beta1.DeploymentSpec{
+				dpl: appsv1.DeploymentSpec{
 					Replicas: ptrInt32(3),
-					Strategy: v1beta1.DeploymentStrategy{
-						Type: v1beta1.RollingUpdateDeploymentStrategyType,
+					Strategy: appsv1.DeploymentStrategy{
+						Type: appsv1.RollingUpdateDeploymentStrategyType,
 					},
 				},
 				errs: []*field.Error{
@@ -97,11 +108,11 @@ func TestDeploymentValidation(t *testing.T) {
 				},
 			},
 			"with a MaxSurge too low": {
-				dpl: v1beta1.DeploymentSpec{
+				dpl: appsv1.DeploymentSpec{
 					Replicas: ptrInt32(10),
-					Strategy: v1beta1.DeploymentStrategy{
-						Type: v1beta1.RollingUpdateDeploymentStrategyType,
-						RollingUpdate: &v1beta1.RollingUpdateDeployment{
+					Strategy: appsv1.DeploymentStrategy{
+						Type: appsv1.RollingUpdateDeploymentStrategyType,
+						RollingUpdate: &appsv1.RollingUpdateDeployment{
 							MaxSurge:       fromIntStr("5%"),
 							MaxUnavailable: fromIntStr("0"),
 						},
@@ -112,11 +123,11 @@ func TestDeploymentValidation(t *testing.T) {
 				},
 			},
 			"with a MaxSurge too high": {
-				dpl: v1beta1.DeploymentSpec{
+				dpl: appsv1.DeploymentSpec{
 					Replicas: ptrInt32(10),
-					Strategy: v1beta1.DeploymentStrategy{
-						Type: v1beta1.RollingUpdateDeploymentStrategyType,
-						RollingUpdate: &v1beta1.RollingUpdateDeployment{
+					Strategy: appsv1.DeploymentStrategy{
+						Type: appsv1.RollingUpdateDeploymentStrategyType,
+						RollingUpdate: &appsv1.RollingUpdateDeployment{
 							MaxSurge:       fromIntStr("95%"),
 							MaxUnavailable: fromIntStr("0"),
 						},
@@ -127,22 +138,22 @@ func TestDeploymentValidation(t *testing.T) {
 				},
 			},
 			"with different update strategy type": {
-				dpl: v1beta1.DeploymentSpec{
+				dpl: appsv1.DeploymentSpec{
 					Replicas: ptrInt32(3),
-					Strategy: v1beta1.DeploymentStrategy{
-						Type: v1beta1.RecreateDeploymentStrategyType,
+					Strategy: appsv1.DeploymentStrategy{
+						Type: appsv1.RecreateDeploymentStrategyType,
 					},
 				},
 				errs: []*field.Error{
-					field.Invalid(field.NewPath("spec").Child("strategy").Child("type"), string(v1beta1.RecreateDeploymentStrategyType), "should be 'RollingUpdate'"),
+					field.Invalid(field.NewPath("spec").Child("strategy").Child("type"), string(appsv1.RecreateDeploymentStrategyType), "should be 'RollingUpdate'"),
 				},
 			},
 			"with a maxUnavailable set too high": {
-				dpl: v1beta1.DeploymentSpec{
+				dpl: appsv1.DeploymentSpec{
 					Replicas: ptrInt32(3),
-					Strategy: v1beta1.DeploymentStrategy{
-						Type: v1beta1.RollingUpdateDeploymentStrategyType,
-						RollingUpdate: &v1beta1.RollingUpdateDeployment{
+					Strategy: appsv1.DeploymentStrategy{
+						Type: appsv1.RollingUpdateDeploymentStrategyType,
+						RollingUpdate: &appsv1.RollingUpdateDeployment{
 							MaxSurge:       fromIntStr("50%"),
 							MaxUnavailable: fromIntStr("1"),
 						},
@@ -157,6 +168,169 @@ func TestDeploymentValidation(t *testing.T) {
 		runTests(t, hap, tcs)
 	})
 
+	t.Run("Rollout", func(t *testing.T) {
+		hap := v1alpha1.HighAvailabilityPolicy{
+			Spec: v1alpha1.HighAvailabilityPolicySpec{
+				Strategy: &v1alpha1.HighAvailabilityPolicyStrategy{
+					Type: appsv1.RecreateDeploymentStrategyType,
+					MinReadySeconds: &v1alpha1.Bounds{
+						Minimum: ptrInt32(5),
+					},
+					ProgressDeadlineSeconds: &v1alpha1.Bounds{
+						Maximum: ptrInt32(600),
+					},
+					RevisionHistoryLimit: &v1alpha1.Bounds{
+						Minimum: ptrInt32(2),
+						Maximum: ptrInt32(10),
+					},
+				},
+			},
+		}
+
+		recreate := appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+
+		tcs := map[string]testCase{
+			"with a valid spec": {
+				dpl: appsv1.DeploymentSpec{
+					Strategy:                recreate,
+					MinReadySeconds:         10,
+					ProgressDeadlineSeconds: ptrInt32(300),
+					RevisionHistoryLimit:    ptrInt32(5),
+				},
+			},
+			"without a deadline and history limit": {
+				dpl: appsv1.DeploymentSpec{
+					Strategy:        recreate,
+					MinReadySeconds: 5,
+				},
+				errs: []*field.Error{
+					field.Invalid(field.NewPath("spec").Child("progressDeadlineSeconds"), nil, "should be at most 600"),
+					field.Invalid(field.NewPath("spec").Child("revisionHistoryLimit"), nil, "should be at most 10"),
+				},
+			},
+			"with values out of bounds": {
+				dpl: appsv1.DeploymentSpec{
+					Strategy:                recreate,
+					ProgressDeadlineSeconds: ptrInt32(1200),
+					RevisionHistoryLimit:    ptrInt32(0),
+				},
+				errs: []*field.Error{
+					field.Invalid(field.NewPath("spec").Child("minReadySeconds"), int64(0), "should be at least 5"),
+					field.Invalid(field.NewPath("spec").Child("progressDeadlineSeconds"), int64(1200), "should be at most 600"),
+					field.Invalid(field.NewPath("spec").Child("revisionHistoryLimit"), int64(0), "should be at least 2"),
+				},
+			},
+		}
+
+		runTests(t, hap, tcs)
+	})
+
+	t.Run("Rollout without a strategy type", func(t *testing.T) {
+		hap := v1alpha1.HighAvailabilityPolicy{
+			Spec: v1alpha1.HighAvailabilityPolicySpec{
+				Strategy: &v1alpha1.HighAvailabilityPolicyStrategy{
+					MinReadySeconds: &v1alpha1.Bounds{
+						Minimum: ptrInt32(5),
+					},
+				},
+			},
+		}
+
+		tcs := map[string]testCase{
+			"with a Recreate strategy": {
+				dpl: appsv1.DeploymentSpec{
+					Strategy:        appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+					MinReadySeconds: 10,
+				},
+			},
+			"with a RollingUpdate strategy": {
+				dpl: appsv1.DeploymentSpec{
+					Replicas: ptrInt32(3),
+					Strategy: appsv1.DeploymentStrategy{
+						Type: appsv1.RollingUpdateDeploymentStrategyType,
+						RollingUpdate: &appsv1.RollingUpdateDeployment{
+							MaxSurge:       fromIntStr("25%"),
+							MaxUnavailable: fromIntStr("25%"),
+						},
+					},
+					MinReadySeconds: 10,
+				},
+			},
+			"with values out of bounds": {
+				dpl: appsv1.DeploymentSpec{
+					Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+				},
+				errs: []*field.Error{
+					field.Invalid(field.NewPath("spec").Child("minReadySeconds"), int64(0), "should be at least 5"),
+				},
+			},
+		}
+
+		runTests(t, hap, tcs)
+	})
+
+	t.Run("Rollout with a strategy type", func(t *testing.T) {
+		hap := v1alpha1.HighAvailabilityPolicy{
+			Spec: v1alpha1.HighAvailabilityPolicySpec{
+				Strategy: &v1alpha1.HighAvailabilityPolicyStrategy{
+					Type: appsv1.RollingUpdateDeploymentStrategyType,
+					MinReadySeconds: &v1alpha1.Bounds{
+						Minimum: ptrInt32(5),
+					},
+				},
+			},
+		}
+
+		tcs := map[string]testCase{
+			"with a RollingUpdate strategy": {
+				dpl: appsv1.DeploymentSpec{
+					Replicas: ptrInt32(3),
+					Strategy: appsv1.DeploymentStrategy{
+						Type: appsv1.RollingUpdateDeploymentStrategyType,
+						RollingUpdate: &appsv1.RollingUpdateDeployment{
+							MaxSurge:       fromIntStr("100%"),
+							MaxUnavailable: fromIntStr("100%"),
+						},
+					},
+					MinReadySeconds: 10,
+				},
+			},
+			"without a rolling update configuration": {
+				dpl: appsv1.DeploymentSpec{
+					Replicas:        ptrInt32(3),
+					Strategy:        appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType},
+					MinReadySeconds: 10,
+				},
+			},
+			"with a Recreate strategy": {
+				dpl: appsv1.DeploymentSpec{
+					Strategy:        appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+					MinReadySeconds: 10,
+				},
+				errs: []*field.Error{
+					field.Invalid(field.NewPath("spec").Child("strategy").Child("type"), string(appsv1.RecreateDeploymentStrategyType), "should be 'RollingUpdate'"),
+				},
+			},
+			"with values out of bounds": {
+				dpl: appsv1.DeploymentSpec{
+					Replicas: ptrInt32(3),
+					Strategy: appsv1.DeploymentStrategy{
+						Type: appsv1.RollingUpdateDeploymentStrategyType,
+						RollingUpdate: &appsv1.RollingUpdateDeployment{
+							MaxSurge:       fromIntStr("25%"),
+							MaxUnavailable: fromIntStr("25%"),
+						},
+					},
+				},
+				errs: []*field.Error{
+					field.Invalid(field.NewPath("spec").Child("minReadySeconds"), int64(0), "should be at least 5"),
+				},
+			},
+		}
+
+		runTests(t, hap, tcs)
+	})
+
 	t.Run("ResourceRequirements", func(t *testing.T) {
 		hap := v1alpha1.HighAvailabilityPolicy{
 			Spec: v1alpha1.HighAvailabilityPolicySpec{
@@ -174,7 +348,7 @@ func TestDeploymentValidation(t *testing.T) {
 		cPath := field.NewPath("spec").Child("template").Child("spec").Child("containers")
 		tcs := map[string]testCase{
 			"with a valid spec": {
-				dpl: v1beta1.DeploymentSpec{
+				dpl: appsv1.DeploymentSpec{
 					Template: v1.PodTemplateSpec{
 						Spec: v1.PodSpec{
 							Containers: []v1.Container{
@@ -194,7 +368,7 @@ func TestDeploymentValidation(t *testing.T) {
 				},
 			},
 			"without requests set": {
-				dpl: v1beta1.DeploymentSpec{
+				dpl: appsv1.DeploymentSpec{
 					Template: v1.PodTemplateSpec{
 						Spec: v1.PodSpec{
 							Containers: []v1.Container{
@@ -214,11 +388,11 @@ func TestDeploymentValidation(t *testing.T) {
 					},
 				},
 				errs: []*field.Error{
-					field.Invalid(cPath.Child("test-container").Child("resources").Child("requests").Child("cpu"), nil, "is required"),
+					field.Invalid(cPath.Index(0).Child("resources").Child("requests").Child("cpu"), nil, "is required"),
 				},
 			},
 			"without limits set": {
-				dpl: v1beta1.DeploymentSpec{
+				dpl: appsv1.DeploymentSpec{
 					Template: v1.PodTemplateSpec{
 						Spec: v1.PodSpec{
 							Containers: []v1.Container{
@@ -238,7 +412,359 @@ func TestDeploymentValidation(t *testing.T) {
 					},
 				},
 				errs: []*field.Error{
-					field.Invalid(cPath.Child("test-container").Child("resources").Child("limits").Child("memory"), nil, "is required"),
+					field.Invalid(cPath.Index(0).Child("resources").Child("limits").Child("memory"), nil, "is required"),
+				},
+			},
+		}
+
+		runTests(t, hap, tcs)
+	})
+
+	t.Run("ResourceQuantities", func(t *testing.T) {
+		hap := v1alpha1.HighAvailabilityPolicy{
+			Spec: v1alpha1.HighAvailabilityPolicySpec{
+				Resources: &v1alpha1.HighAvailabilityPolicyResourceRequirements{
+					MinRequests: v1.ResourceList{
+						v1.ResourceCPU: resource.MustParse("100m"),
+					},
+					MaxRequests: v1.ResourceList{
+						v1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+					MaxLimits: v1.ResourceList{
+						v1.ResourceMemory: resource.MustParse("2Gi"),
+					},
+				},
+			},
+		}
+
+		cPath := field.NewPath("spec").Child("template").Child("spec").Child("containers")
+		tcs := map[string]testCase{
+			"with a valid spec": {
+				dpl: deploymentSpecWithResources(
+					v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("250m"),
+							v1.ResourceMemory: resource.MustParse("512Mi"),
+						},
+						Limits: v1.ResourceList{
+							v1.ResourceMemory: resource.MustParse("1Gi"),
+						},
+					},
+				),
+			},
+			"without a request for a resource with a minimum": {
+				dpl: deploymentSpecWithResources(
+					v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceMemory: resource.MustParse("512Mi"),
+						},
+					},
+				),
+				errs: []*field.Error{
+					field.Invalid(cPath.Index(0).Child("resources").Child("requests").Child("cpu"), nil, "is required"),
+				},
+			},
+			"with a request below the minimum": {
+				dpl: deploymentSpecWithResources(
+					v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU: resource.MustParse("1m"),
+						},
+					},
+				),
+				errs: []*field.Error{
+					field.Invalid(cPath.Index(0).Child("resources").Child("requests").Child("cpu"), "1m", "should be at least 100m"),
+				},
+			},
+			"with a request and limit above the maximum": {
+				dpl: deploymentSpecWithResources(
+					v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("100m"),
+							v1.ResourceMemory: resource.MustParse("2Gi"),
+						},
+						Limits: v1.ResourceList{
+							v1.ResourceMemory: resource.MustParse("4Gi"),
+						},
+					},
+				),
+				errs: []*field.Error{
+					field.Invalid(cPath.Index(0).Child("resources").Child("requests").Child("memory"), "2Gi", "should be at most 1Gi"),
+					field.Invalid(cPath.Index(0).Child("resources").Child("limits").Child("memory"), "4Gi", "should be at most 2Gi"),
+				},
+			},
+			"with a second container below the minimum": {
+				dpl: deploymentSpecWithResources(
+					v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU: resource.MustParse("100m"),
+						},
+					},
+					v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU: resource.MustParse("10m"),
+						},
+					},
+				),
+				errs: []*field.Error{
+					field.Invalid(cPath.Index(1).Child("resources").Child("requests").Child("cpu"), "10m", "should be at least 100m"),
+				},
+			},
+		}
+
+		runTests(t, hap, tcs)
+	})
+
+	t.Run("Probes", func(t *testing.T) {
+		hap := v1alpha1.HighAvailabilityPolicy{
+			Spec: v1alpha1.HighAvailabilityPolicySpec{
+				Probes: &v1alpha1.HighAvailabilityPolicyProbes{
+					Containers: "app-*",
+					Readiness: &v1alpha1.HighAvailabilityPolicyProbe{
+						PeriodSeconds: &v1alpha1.Bounds{
+							Maximum: ptrInt32(10),
+						},
+						FailureThreshold: &v1alpha1.Bounds{
+							Minimum: ptrInt32(2),
+						},
+					},
+					Liveness: &v1alpha1.HighAvailabilityPolicyProbe{
+						InitialDelaySeconds: &v1alpha1.Bounds{
+							Minimum: ptrInt32(5),
+						},
+					},
+				},
+			},
+		}
+
+		cPath := field.NewPath("spec").Child("template").Child("spec").Child("containers")
+		probe := func(initialDelay, period, failureThreshold int32) *v1.Probe {
+			return &v1.Probe{
+				InitialDelaySeconds: initialDelay,
+				PeriodSeconds:       period,
+				FailureThreshold:    failureThreshold,
+			}
+		}
+
+		tcs := map[string]testCase{
+			"with a valid spec": {
+				dpl: deploymentSpecWithContainers(
+					v1.Container{Name: "app-web", ReadinessProbe: probe(0, 5, 3), LivenessProbe: probe(10, 0, 0)},
+				),
+			},
+			"with the default probe values": {
+				dpl: deploymentSpecWithContainers(
+					v1.Container{Name: "app-web", ReadinessProbe: probe(0, 0, 0), LivenessProbe: probe(5, 0, 0)},
+				),
+			},
+			"with a container which doesn't match the pattern": {
+				dpl: deploymentSpecWithContainers(
+					v1.Container{Name: "app-web", ReadinessProbe: probe(0, 5, 3), LivenessProbe: probe(10, 0, 0)},
+					v1.Container{Name: "sidecar"},
+				),
+			},
+			"without probes": {
+				dpl: deploymentSpecWithContainers(
+					v1.Container{Name: "sidecar"},
+					v1.Container{Name: "app-web"},
+				),
+				errs: []*field.Error{
+					field.Invalid(cPath.Index(1).Child("readinessProbe"), nil, "is required"),
+					field.Invalid(cPath.Index(1).Child("livenessProbe"), nil, "is required"),
+				},
+			},
+			"with probe values out of bounds": {
+				dpl: deploymentSpecWithContainers(
+					v1.Container{Name: "app-web", ReadinessProbe: probe(0, 30, 1), LivenessProbe: probe(0, 0, 0)},
+				),
+				errs: []*field.Error{
+					field.Invalid(cPath.Index(0).Child("readinessProbe").Child("periodSeconds"), int64(30), "should be at most 10"),
+					field.Invalid(cPath.Index(0).Child("readinessProbe").Child("failureThreshold"), int64(1), "should be at least 2"),
+					field.Invalid(cPath.Index(0).Child("livenessProbe").Child("initialDelaySeconds"), int64(0), "should be at least 5"),
+				},
+			},
+		}
+
+		runTests(t, hap, tcs)
+
+		hap.Spec.Probes.Containers = "["
+		runTests(t, hap, map[string]testCase{
+			"with an invalid container pattern": {
+				dpl: deploymentSpecWithContainers(v1.Container{Name: "app-web"}),
+				errs: []*field.Error{
+					field.Invalid(cPath, "[", "can't be matched against the container pattern of the policy: syntax error in pattern"),
+				},
+			},
+		})
+	})
+
+	t.Run("Shutdown", func(t *testing.T) {
+		hap := v1alpha1.HighAvailabilityPolicy{
+			Spec: v1alpha1.HighAvailabilityPolicySpec{
+				Shutdown: &v1alpha1.HighAvailabilityPolicyShutdown{
+					PreStop: true,
+					TerminationGracePeriodSeconds: &v1alpha1.Bounds{
+						Minimum: ptrInt32(10),
+						Maximum: ptrInt32(60),
+					},
+				},
+			},
+		}
+
+		sPath := field.NewPath("spec").Child("template").Child("spec")
+		preStop := &v1.Lifecycle{
+			PreStop: &v1.Handler{
+				Exec: &v1.ExecAction{Command: []string{"sleep", "5"}},
+			},
+		}
+		ports := []v1.ContainerPort{{ContainerPort: 8080}}
+		withGracePeriod := func(spec appsv1.DeploymentSpec, seconds int64) appsv1.DeploymentSpec {
+			spec.Template.Spec.TerminationGracePeriodSeconds = &seconds
+			return spec
+		}
+
+		tcs := map[string]testCase{
+			"with a valid spec": {
+				dpl: withGracePeriod(deploymentSpecWithContainers(
+					v1.Container{Name: "web", Ports: ports, Lifecycle: preStop},
+				), 45),
+			},
+			"with the default grace period": {
+				dpl: deploymentSpecWithContainers(
+					v1.Container{Name: "web", Ports: ports, Lifecycle: preStop},
+				),
+			},
+			"without a preStop handler on a container without ports": {
+				dpl: deploymentSpecWithContainers(
+					v1.Container{Name: "web", Ports: ports, Lifecycle: preStop},
+					v1.Container{Name: "worker"},
+				),
+			},
+			"without a preStop handler": {
+				dpl: deploymentSpecWithContainers(
+					v1.Container{Name: "worker"},
+					v1.Container{Name: "web", Ports: ports},
+				),
+				errs: []*field.Error{
+					field.Invalid(sPath.Child("containers").Index(1).Child("lifecycle").Child("preStop"), nil, "is required"),
+				},
+			},
+			"with a grace period too short": {
+				dpl: withGracePeriod(deploymentSpecWithContainers(
+					v1.Container{Name: "web", Ports: ports, Lifecycle: preStop},
+				), 0),
+				errs: []*field.Error{
+					field.Invalid(sPath.Child("terminationGracePeriodSeconds"), int64(0), "should be at least 10"),
+				},
+			},
+			"with a grace period too long": {
+				dpl: withGracePeriod(deploymentSpecWithContainers(
+					v1.Container{Name: "web", Ports: ports, Lifecycle: preStop},
+				), 120),
+				errs: []*field.Error{
+					field.Invalid(sPath.Child("terminationGracePeriodSeconds"), int64(120), "should be at most 60"),
+				},
+			},
+		}
+
+		runTests(t, hap, tcs)
+	})
+
+	t.Run("PodAntiAffinity", func(t *testing.T) {
+		hap := v1alpha1.HighAvailabilityPolicy{
+			Spec: v1alpha1.HighAvailabilityPolicySpec{
+				Scheduling: &v1alpha1.HighAvailabilityPolicyScheduling{
+					PodAntiAffinity: &v1alpha1.HighAvailabilityPolicyPodAntiAffinity{
+						TopologyKey: "kubernetes.io/hostname",
+					},
+				},
+			},
+		}
+
+		path := field.NewPath("spec").Child("template").Child("spec").Child("affinity").Child("podAntiAffinity")
+		rPath := path.Child("requiredDuringSchedulingIgnoredDuringExecution")
+		selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}}
+
+		tcs := map[string]testCase{
+			"with a required term": {
+				dpl: deploymentSpecWithAntiAffinity(
+					[]v1.PodAffinityTerm{antiAffinityTerm("kubernetes.io/hostname", map[string]string{"app": "web"})},
+					nil,
+				),
+			},
+			"with a preferred term": {
+				dpl: deploymentSpecWithAntiAffinity(
+					nil,
+					[]v1.PodAffinityTerm{antiAffinityTerm("kubernetes.io/hostname", map[string]string{"app": "web"})},
+				),
+			},
+			"without an affinity": {
+				dpl: appsv1.DeploymentSpec{},
+				errs: []*field.Error{
+					field.Invalid(path, nil, "is required"),
+				},
+			},
+			"with a term for a different topology key": {
+				dpl: deploymentSpecWithAntiAffinity(
+					[]v1.PodAffinityTerm{antiAffinityTerm("failure-domain.beta.kubernetes.io/zone", map[string]string{"app": "web"})},
+					nil,
+				),
+				errs: []*field.Error{
+					field.Invalid(path, nil, "should contain a term with topologyKey 'kubernetes.io/hostname'"),
+				},
+			},
+			"with a term which doesn't select the pods": {
+				dpl: deploymentSpecWithAntiAffinity(
+					[]v1.PodAffinityTerm{antiAffinityTerm("kubernetes.io/hostname", map[string]string{"app": "api"})},
+					nil,
+				),
+				errs: []*field.Error{
+					field.Invalid(rPath.Index(0).Child("labelSelector"), selector, "should select the pod template labels"),
+				},
+			},
+			"with a term for a different namespace": {
+				dpl: deploymentSpecWithAntiAffinity(
+					nil,
+					[]v1.PodAffinityTerm{
+						{
+							LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+							Namespaces:    []string{"other"},
+							TopologyKey:   "kubernetes.io/hostname",
+						},
+					},
+				),
+				errs: []*field.Error{
+					field.Invalid(path.Child("preferredDuringSchedulingIgnoredDuringExecution").Index(0).Child("podAffinityTerm").Child("namespaces"), []string{"other"}, "should contain ''"),
+				},
+			},
+			"with a term which doesn't select the pods and one which does": {
+				dpl: deploymentSpecWithAntiAffinity(
+					[]v1.PodAffinityTerm{
+						antiAffinityTerm("kubernetes.io/hostname", map[string]string{"app": "api"}),
+						antiAffinityTerm("kubernetes.io/hostname", map[string]string{"app": "web"}),
+					},
+					nil,
+				),
+			},
+		}
+
+		runTests(t, hap, tcs)
+
+		hap.Spec.Scheduling.PodAntiAffinity.Type = v1alpha1.PodAntiAffinityRequired
+		tcs = map[string]testCase{
+			"with a required term": {
+				dpl: deploymentSpecWithAntiAffinity(
+					[]v1.PodAffinityTerm{antiAffinityTerm("kubernetes.io/hostname", map[string]string{"app": "web"})},
+					nil,
+				),
+			},
+			"with a preferred term when a required term is required": {
+				dpl: deploymentSpecWithAntiAffinity(
+					nil,
+					[]v1.PodAffinityTerm{antiAffinityTerm("kubernetes.io/hostname", map[string]string{"app": "web"})},
+				),
+				errs: []*field.Error{
+					field.Invalid(rPath, nil, "should contain a term with topologyKey 'kubernetes.io/hostname'"),
 				},
 			},
 		}
@@ -247,30 +773,233 @@ func TestDeploymentValidation(t *testing.T) {
 	})
 }
 
-func runTests(t *testing.T, hap v1alpha1.HighAvailabilityPolicy, tcs testCases) {
+func TestDisruptionValidation(t *testing.T) {
+	hap := v1alpha1.HighAvailabilityPolicy{
+		Spec: v1alpha1.HighAvailabilityPolicySpec{
+			Disruptions: &v1alpha1.HighAvailabilityPolicyDisruptions{
+				Budgetted: true,
+				Unique:    true,
+			},
+		},
+	}
+
+	lblPath := field.NewPath("spec").Child("template").Child("metadata").Child("labels")
+	dpl := deploymentWithPodLabels("web", map[string]string{"app": "web"})
+
+	tcs := map[string]struct {
+		pdbs []policyv1beta1.PodDisruptionBudget
+		dpls []appsv1.Deployment
+		errs []*field.Error
+	}{
+		"with a unique budget": {
+			pdbs: []policyv1beta1.PodDisruptionBudget{
+				budgetWithSelector("web", map[string]string{"app": "web"}),
+			},
+			dpls: []appsv1.Deployment{
+				dpl,
+				deploymentWithPodLabels("worker", map[string]string{"app": "worker"}),
+			},
+		},
+		"without a budget": {
+			pdbs: []policyv1beta1.PodDisruptionBudget{
+				budgetWithSelector("worker", map[string]string{"app": "worker"}),
+			},
+			errs: []*field.Error{
+				field.Invalid(lblPath, dpl.Spec.Template.Labels, "should be selected by a PodDisruptionBudget"),
+			},
+		},
+		"with a budget with an empty selector": {
+			pdbs: []policyv1beta1.PodDisruptionBudget{
+				budgetWithSelector("all", map[string]string{}),
+			},
+			errs: []*field.Error{
+				field.Invalid(lblPath, dpl.Spec.Template.Labels, "should be selected by a PodDisruptionBudget"),
+			},
+		},
+		"with multiple budgets": {
+			pdbs: []policyv1beta1.PodDisruptionBudget{
+				budgetWithSelector("web", map[string]string{"app": "web"}),
+				budgetWithSelector("web-too", map[string]string{"app": "web"}),
+			},
+			errs: []*field.Error{
+				field.Invalid(lblPath, dpl.Spec.Template.Labels, "should be selected by a single PodDisruptionBudget, selected by 'web', 'web-too'"),
+			},
+		},
+		"with a budget selecting another deployment": {
+			pdbs: []policyv1beta1.PodDisruptionBudget{
+				budgetWithSelector("web", map[string]string{"app": "web"}),
+			},
+			dpls: []appsv1.Deployment{
+				deploymentWithPodLabels("web-canary", map[string]string{"app": "web", "track": "canary"}),
+			},
+			errs: []*field.Error{
+				field.Invalid(lblPath, dpl.Spec.Template.Labels, "PodDisruptionBudget 'web' also selects Deployment 'web-canary'"),
+			},
+		},
+		"with a budget which doesn't allow disruptions": {
+			pdbs: []policyv1beta1.PodDisruptionBudget{
+				budgetWithMinAvailable(budgetWithSelector("web", map[string]string{"app": "web"}), intstr.FromInt(1)),
+			},
+			errs: []*field.Error{
+				field.Invalid(lblPath, dpl.Spec.Template.Labels, "PodDisruptionBudget 'web' spec.minAvailable should allow a pod of Deployment 'web' with 1 replicas to be disrupted"),
+			},
+		},
+	}
+
 	for n, tc := range tcs {
 		t.Run(n, func(t *testing.T) {
-			errs := validation.ValidateDeployment(v1beta1.Deployment{Spec: tc.dpl}, hap)
+			errs := validation.ValidateDisruptions(dpl, tc.pdbs, tc.dpls, hap)
+			expectErrors(t, tc.errs, errs)
+		})
+	}
+}
 
-			if len(errs) != len(tc.errs) {
-				t.Errorf("Expected '%d' errors, got '%d'", len(tc.errs), len(errs))
-				return
-			}
+func TestDisruptionBudgetValidation(t *testing.T) {
+	hap := v1alpha1.HighAvailabilityPolicy{
+		Spec: v1alpha1.HighAvailabilityPolicySpec{
+			Disruptions: &v1alpha1.HighAvailabilityPolicyDisruptions{
+				Budgetted: true,
+			},
+		},
+	}
 
-			for i, e := range errs {
-				expectedErr := tc.errs[i]
-				if !reflect.DeepEqual(e, expectedErr) {
-					t.Errorf("Expected\n%v\nbut got \n%v", expectedErr, e)
-				}
-			}
+	dpl := deploymentWithPodLabels("web", map[string]string{"app": "web"})
+	dpl.Spec.Replicas = ptrInt32(4)
+
+	pdb := budgetWithSelector("web", map[string]string{"app": "web"})
+	minPath := field.NewPath("spec").Child("minAvailable")
+	maxPath := field.NewPath("spec").Child("maxUnavailable")
+
+	tcs := map[string]struct {
+		pdb  policyv1beta1.PodDisruptionBudget
+		errs []*field.Error
+	}{
+		"with a minimum available": {
+			pdb: budgetWithMinAvailable(pdb, intstr.FromInt(3)),
+		},
+		"with a maximum unavailable percentage": {
+			pdb: budgetWithMaxUnavailable(pdb, intstr.FromString("25%")),
+		},
+		"with a minimum available of all replicas": {
+			pdb: budgetWithMinAvailable(pdb, intstr.FromString("100%")),
+			errs: []*field.Error{
+				field.Invalid(minPath, "100%", "should allow a pod of Deployment 'web' with 4 replicas to be disrupted"),
+			},
+		},
+		"with a minimum available which rounds up to all replicas": {
+			pdb: budgetWithMinAvailable(pdb, intstr.FromString("80%")),
+			errs: []*field.Error{
+				field.Invalid(minPath, "80%", "should allow a pod of Deployment 'web' with 4 replicas to be disrupted"),
+			},
+		},
+		"without a minimum available": {
+			pdb: budgetWithMinAvailable(pdb, intstr.FromInt(0)),
+			errs: []*field.Error{
+				field.Invalid(minPath, "0", "should keep a pod of Deployment 'web' with 4 replicas available"),
+			},
+		},
+		"with a maximum unavailable of zero": {
+			pdb: budgetWithMaxUnavailable(pdb, intstr.FromInt(0)),
+			errs: []*field.Error{
+				field.Invalid(maxPath, "0", "should allow a pod of Deployment 'web' with 4 replicas to be disrupted"),
+			},
+		},
+		"with a maximum unavailable of all replicas": {
+			pdb: budgetWithMaxUnavailable(pdb, intstr.FromString("100%")),
+			errs: []*field.Error{
+				field.Invalid(maxPath, "100%", "should keep a pod of Deployment 'web' with 4 replicas available"),
+			},
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			errs := validation.ValidateDisruptionBudget(tc.pdb, dpl, nil, nil, hap)
+			expectErrors(t, tc.errs, errs)
+		})
+	}
+
+	t.Run("without a disruptions configuration", func(t *testing.T) {
+		errs := validation.ValidateDisruptionBudget(budgetWithMaxUnavailable(pdb, intstr.FromInt(0)), dpl, nil, nil, v1alpha1.HighAvailabilityPolicy{})
+		expectErrors(t, nil, errs)
+	})
+
+	t.Run("with unique budgets", func(t *testing.T) {
+		hap := *hap.DeepCopy()
+		hap.Spec.Disruptions.Unique = true
+
+		pdb := budgetWithMaxUnavailable(pdb, intstr.FromInt(1))
+		selPath := field.NewPath("spec").Child("selector")
+
+		tcs := map[string]struct {
+			pdbs []policyv1beta1.PodDisruptionBudget
+			dpls []appsv1.Deployment
+			errs []*field.Error
+		}{
+			"with a unique budget": {
+				pdbs: []policyv1beta1.PodDisruptionBudget{
+					pdb,
+					budgetWithSelector("worker", map[string]string{"app": "worker"}),
+				},
+				dpls: []appsv1.Deployment{
+					dpl,
+					deploymentWithPodLabels("worker", map[string]string{"app": "worker"}),
+				},
+			},
+			"with another budget selecting the deployment": {
+				pdbs: []policyv1beta1.PodDisruptionBudget{
+					budgetWithSelector("web-too", map[string]string{"app": "web"}),
+				},
+				errs: []*field.Error{
+					field.Invalid(selPath, "app=web", "should be the only PodDisruptionBudget selecting Deployment 'web', also selected by 'web-too'"),
+				},
+			},
+			"with a budget selecting another deployment": {
+				dpls: []appsv1.Deployment{
+					deploymentWithPodLabels("web-canary", map[string]string{"app": "web", "track": "canary"}),
+				},
+				errs: []*field.Error{
+					field.Invalid(selPath, "app=web", "should only select Deployment 'web', also selects Deployment 'web-canary'"),
+				},
+			},
+		}
+
+		for n, tc := range tcs {
+			t.Run(n, func(t *testing.T) {
+				errs := validation.ValidateDisruptionBudget(pdb, dpl, tc.pdbs, tc.dpls, hap)
+				expectErrors(t, tc.errs, errs)
+			})
+		}
+	})
+}
+
+func runTests(t *testing.T, hap v1alpha1.HighAvailabilityPolicy, tcs testCases) {
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			errs := validation.ValidateDeployment(appsv1.Deployment{Spec: tc.dpl}, hap)
+			expectErrors(t, tc.errs, errs)
 		})
 	}
 }
 
+func expectErrors(t *testing.T, expected []*field.Error, errs field.ErrorList) {
+	if len(errs) != len(expected) {
+		t.Errorf("Expected '%d' errors, got '%d'", len(expected), len(errs))
+		return
+	}
+
+	for i, e := range errs {
+		expectedErr := expected[i]
+		if !reflect.DeepEqual(e, expectedErr) {
+			t.Errorf("Expected\n%v\nbut got \n%v", expectedErr, e)
+		}
+	}
+}
+
 type testCases map[string]testCase
 
 type testCase struct {
-	dpl  v1beta1.DeploymentSpec
+	dpl  appsv1.DeploymentSpec
 	errs []*field.Error
 }
 
@@ -282,3 +1011,92 @@ func fromIntStr(v string) *intstr.IntOrString {
 	sv := intstr.FromString(v)
 	return &sv
 }
+
+func deploymentWithPodLabels(name string, lbls map[string]string) appsv1.Deployment {
+	return appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: appsv1.DeploymentSpec{
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: lbls},
+			},
+		},
+	}
+}
+
+func budgetWithSelector(name string, lbls map[string]string) policyv1beta1.PodDisruptionBudget {
+	return policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: lbls},
+		},
+	}
+}
+
+func budgetWithMinAvailable(pdb policyv1beta1.PodDisruptionBudget, minAvailable intstr.IntOrString) policyv1beta1.PodDisruptionBudget {
+	pdb.Spec.MinAvailable = &minAvailable
+	return pdb
+}
+
+func budgetWithMaxUnavailable(pdb policyv1beta1.PodDisruptionBudget, maxUnavailable intstr.IntOrString) policyv1beta1.PodDisruptionBudget {
+	pdb.Spec.MaxUnavailable = &maxUnavailable
+	return pdb
+}
+
+func deploymentSpecWithResources(resources ...v1.ResourceRequirements) appsv1.DeploymentSpec {
+	containers := make([]v1.Container, len(resources))
+	for i, rr := range resources {
+		containers[i] = v1.Container{
+			Name:      fmt.Sprintf("container-%d", i),
+			Resources: rr,
+		}
+	}
+
+	return appsv1.DeploymentSpec{
+		Template: v1.PodTemplateSpec{
+			Spec: v1.PodSpec{
+				Containers: containers,
+			},
+		},
+	}
+}
+
+func deploymentSpecWithAntiAffinity(required, preferred []v1.PodAffinityTerm) appsv1.DeploymentSpec {
+	affinity := &v1.PodAntiAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: required,
+	}
+
+	for _, term := range preferred {
+		affinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+			affinity.PreferredDuringSchedulingIgnoredDuringExecution,
+			v1.WeightedPodAffinityTerm{Weight: 100, PodAffinityTerm: term},
+		)
+	}
+
+	return appsv1.DeploymentSpec{
+		Template: v1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"app": "web"},
+			},
+			Spec: v1.PodSpec{
+				Affinity: &v1.Affinity{PodAntiAffinity: affinity},
+			},
+		},
+	}
+}
+
+func antiAffinityTerm(topologyKey string, lbls map[string]string) v1.PodAffinityTerm {
+	return v1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{MatchLabels: lbls},
+		TopologyKey:   topologyKey,
+	}
+}
+
+func deploymentSpecWithContainers(containers ...v1.Container) appsv1.DeploymentSpec {
+	return appsv1.DeploymentSpec{
+		Template: v1.PodTemplateSpec{
+			Spec: v1.PodSpec{
+				Containers: containers,
+			},
+		},
+	}
+}
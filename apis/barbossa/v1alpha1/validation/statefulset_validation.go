@@ -0,0 +1,97 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateStatefulSet validates the StatefulSet based on a
+// HighAvailabilityPolicy and ensures that all fields that are required are set
+// correctly. The replica count, resource requirements, probes, shutdown and pod
+// anti-affinity are validated the same way they are for a Deployment.
+func ValidateStatefulSet(sts appsv1.StatefulSet, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+	el := field.ErrorList{}
+
+	el = validateReplicaCount(el, sts.Spec.Replicas, hap)
+	el = validateStatefulSetUpdateStrategy(el, sts, hap)
+	el = validatePodManagementPolicy(el, sts, hap)
+	el = validateResourceRequirements(el, sts.Spec.Template.Spec, hap)
+	el = validateProbes(el, sts.Spec.Template.Spec, hap)
+	el = validateShutdown(el, sts.Spec.Template.Spec, hap)
+	el = validatePodAntiAffinity(el, sts.Namespace, sts.Spec.Template, hap)
+
+	return el
+}
+
+func validateStatefulSetUpdateStrategy(el field.ErrorList, sts appsv1.StatefulSet, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+	if hap.Spec.StatefulSet == nil || hap.Spec.StatefulSet.UpdateStrategy == nil {
+		return el
+	}
+
+	path := specPath.Child("updateStrategy")
+	stsStrategy := sts.Spec.UpdateStrategy
+	hapStrategy := hap.Spec.StatefulSet.UpdateStrategy
+
+	if stsStrategy.Type != hapStrategy.Type {
+		return append(el, field.Invalid(path.Child("type"), string(stsStrategy.Type), fmt.Sprintf("should be '%s'", hapStrategy.Type)))
+	}
+
+	if stsStrategy.Type != appsv1.RollingUpdateStatefulSetStrategyType || hapStrategy.RollingUpdate == nil {
+		return el
+	}
+
+	// validate the partition. It needs to be sts.partition <= hap.maxPartition.
+	// Without a partition configured, all pods are updated which is the same
+	// as a partition of 0.
+	maxPartition := hapStrategy.RollingUpdate.MaxPartition
+	if maxPartition == nil || stsStrategy.RollingUpdate == nil || stsStrategy.RollingUpdate.Partition == nil {
+		return el
+	}
+
+	partition := stsStrategy.RollingUpdate.Partition
+	if *partition > *maxPartition {
+		return append(el, field.Invalid(path.Child("rollingUpdate").Child("partition"), partition, fmt.Sprintf("should be at most %d", *maxPartition)))
+	}
+
+	return el
+}
+
+func validatePodManagementPolicy(el field.ErrorList, sts appsv1.StatefulSet, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+	if hap.Spec.StatefulSet == nil || hap.Spec.StatefulSet.PodManagementPolicy == "" {
+		return el
+	}
+
+	// an empty policy is defaulted to OrderedReady by the API server.
+	policy := sts.Spec.PodManagementPolicy
+	if policy == "" {
+		policy = appsv1.OrderedReadyPodManagement
+	}
+
+	if policy != hap.Spec.StatefulSet.PodManagementPolicy {
+		return append(el, field.Invalid(specPath.Child("podManagementPolicy"), string(policy), fmt.Sprintf("should be '%s'", hap.Spec.StatefulSet.PodManagementPolicy)))
+	}
+
+	return el
+}
+
+// ValidateStatefulSetDisruptionBudget validates the PodDisruptionBudget
+// against the StatefulSet it selects, the same way ValidateDisruptionBudget
+// does for a Deployment. The given lists of budgets and statefulsets are the
+// other PodDisruptionBudgets and StatefulSets living in the same namespace.
+func ValidateStatefulSetDisruptionBudget(pdb policyv1beta1.PodDisruptionBudget, sts appsv1.StatefulSet, pdbs []policyv1beta1.PodDisruptionBudget, stss []appsv1.StatefulSet, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+	others := make([]budgetTarget, len(stss))
+	for i, other := range stss {
+		others[i] = statefulSetTarget(other)
+	}
+
+	return validateDisruptionBudget(field.ErrorList{}, pdb, statefulSetTarget(sts), pdbs, others, hap)
+}
+
+func statefulSetTarget(sts appsv1.StatefulSet) budgetTarget {
+	return budgetTarget{kind: "StatefulSet", name: sts.Name, replicas: sts.Spec.Replicas, podLabels: sts.Spec.Template.Labels}
+}
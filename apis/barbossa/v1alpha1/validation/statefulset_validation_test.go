@@ -0,0 +1,215 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1/validation"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestStatefulSetValidation(t *testing.T) {
+	hap := v1alpha1.HighAvailabilityPolicy{
+		Spec: v1alpha1.HighAvailabilityPolicySpec{
+			Replicas: &v1alpha1.HighAvailabilityPolicyReplicas{
+				Minimum: 3,
+			},
+			StatefulSet: &v1alpha1.HighAvailabilityPolicyStatefulSet{
+				UpdateStrategy: &v1alpha1.HighAvailabilityPolicyStatefulSetUpdateStrategy{
+					Type: appsv1.RollingUpdateStatefulSetStrategyType,
+					RollingUpdate: &v1alpha1.HighAvailabilityPolicyStatefulSetRollingUpdate{
+						MaxPartition: ptrInt32(1),
+					},
+				},
+				PodManagementPolicy: appsv1.OrderedReadyPodManagement,
+			},
+			Resources: &v1alpha1.HighAvailabilityPolicyResourceRequirements{
+				Requests: v1alpha1.ResourceList{
+					v1.ResourceMemory: true,
+				},
+			},
+		},
+	}
+
+	validSpec := func() appsv1.StatefulSetSpec {
+		return appsv1.StatefulSetSpec{
+			Replicas: ptrInt32(3),
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type: appsv1.RollingUpdateStatefulSetStrategyType,
+			},
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: "db",
+							Resources: v1.ResourceRequirements{
+								Requests: v1.ResourceList{
+									v1.ResourceMemory: resource.MustParse("1Gi"),
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tcs := map[string]struct {
+		sts  func(appsv1.StatefulSetSpec) appsv1.StatefulSetSpec
+		errs []*field.Error
+	}{
+		"with a valid spec": {
+			sts: func(spec appsv1.StatefulSetSpec) appsv1.StatefulSetSpec {
+				return spec
+			},
+		},
+		"with an invalid replica count": {
+			sts: func(spec appsv1.StatefulSetSpec) appsv1.StatefulSetSpec {
+				spec.Replicas = ptrInt32(1)
+				return spec
+			},
+			errs: []*field.Error{
+				field.Invalid(field.NewPath("spec").Child("replicas"), ptrInt32(1), "should be at least 3"),
+			},
+		},
+		"with a different update strategy type": {
+			sts: func(spec appsv1.StatefulSetSpec) appsv1.StatefulSetSpec {
+				spec.UpdateStrategy.Type = appsv1.OnDeleteStatefulSetStrategyType
+				return spec
+			},
+			errs: []*field.Error{
+				field.Invalid(field.NewPath("spec").Child("updateStrategy").Child("type"), string(appsv1.OnDeleteStatefulSetStrategyType), "should be 'RollingUpdate'"),
+			},
+		},
+		"with a partition within bounds": {
+			sts: func(spec appsv1.StatefulSetSpec) appsv1.StatefulSetSpec {
+				spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{
+					Partition: ptrInt32(1),
+				}
+				return spec
+			},
+		},
+		"with a partition too high": {
+			sts: func(spec appsv1.StatefulSetSpec) appsv1.StatefulSetSpec {
+				spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{
+					Partition: ptrInt32(2),
+				}
+				return spec
+			},
+			errs: []*field.Error{
+				field.Invalid(field.NewPath("spec").Child("updateStrategy").Child("rollingUpdate").Child("partition"), ptrInt32(2), "should be at most 1"),
+			},
+		},
+		"with a different pod management policy": {
+			sts: func(spec appsv1.StatefulSetSpec) appsv1.StatefulSetSpec {
+				spec.PodManagementPolicy = appsv1.ParallelPodManagement
+				return spec
+			},
+			errs: []*field.Error{
+				field.Invalid(field.NewPath("spec").Child("podManagementPolicy"), string(appsv1.ParallelPodManagement), "should be 'OrderedReady'"),
+			},
+		},
+		"without resource requests": {
+			sts: func(spec appsv1.StatefulSetSpec) appsv1.StatefulSetSpec {
+				spec.Template.Spec.Containers[0].Resources = v1.ResourceRequirements{}
+				return spec
+			},
+			errs: []*field.Error{
+				field.Invalid(field.NewPath("spec").Child("template").Child("spec").Child("containers").Index(0).Child("resources").Child("requests").Child("memory"), nil, "is required"),
+			},
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			errs := validation.ValidateStatefulSet(appsv1.StatefulSet{Spec: tc.sts(validSpec())}, hap)
+			expectErrors(t, tc.errs, errs)
+		})
+	}
+}
+
+func TestStatefulSetDisruptionBudgetValidation(t *testing.T) {
+	hap := v1alpha1.HighAvailabilityPolicy{
+		Spec: v1alpha1.HighAvailabilityPolicySpec{
+			Disruptions: &v1alpha1.HighAvailabilityPolicyDisruptions{
+				Budgetted: true,
+				Unique:    true,
+			},
+		},
+	}
+
+	sts := statefulSetWithPodLabels("db", map[string]string{"app": "db"})
+	sts.Spec.Replicas = ptrInt32(3)
+
+	pdb := budgetWithSelector("db", map[string]string{"app": "db"})
+	minPath := field.NewPath("spec").Child("minAvailable")
+	maxPath := field.NewPath("spec").Child("maxUnavailable")
+	selPath := field.NewPath("spec").Child("selector")
+
+	tcs := map[string]struct {
+		pdb  policyv1beta1.PodDisruptionBudget
+		pdbs []policyv1beta1.PodDisruptionBudget
+		stss []appsv1.StatefulSet
+		errs []*field.Error
+	}{
+		"with a maximum unavailable": {
+			pdb: budgetWithMaxUnavailable(pdb, intstr.FromInt(1)),
+		},
+		"with a minimum available of all replicas": {
+			pdb: budgetWithMinAvailable(pdb, intstr.FromInt(3)),
+			errs: []*field.Error{
+				field.Invalid(minPath, "3", "should allow a pod of StatefulSet 'db' with 3 replicas to be disrupted"),
+			},
+		},
+		"with a maximum unavailable of all replicas": {
+			pdb: budgetWithMaxUnavailable(pdb, intstr.FromString("100%")),
+			errs: []*field.Error{
+				field.Invalid(maxPath, "100%", "should keep a pod of StatefulSet 'db' with 3 replicas available"),
+			},
+		},
+		"with another budget selecting the statefulset": {
+			pdb: budgetWithMaxUnavailable(pdb, intstr.FromInt(1)),
+			pdbs: []policyv1beta1.PodDisruptionBudget{
+				budgetWithSelector("db-too", map[string]string{"app": "db"}),
+			},
+			errs: []*field.Error{
+				field.Invalid(selPath, "app=db", "should be the only PodDisruptionBudget selecting StatefulSet 'db', also selected by 'db-too'"),
+			},
+		},
+		"with a budget selecting another statefulset": {
+			pdb: budgetWithMaxUnavailable(pdb, intstr.FromInt(1)),
+			stss: []appsv1.StatefulSet{
+				sts,
+				statefulSetWithPodLabels("db-replica", map[string]string{"app": "db", "role": "replica"}),
+			},
+			errs: []*field.Error{
+				field.Invalid(selPath, "app=db", "should only select StatefulSet 'db', also selects StatefulSet 'db-replica'"),
+			},
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			errs := validation.ValidateStatefulSetDisruptionBudget(tc.pdb, sts, tc.pdbs, tc.stss, hap)
+			expectErrors(t, tc.errs, errs)
+		})
+	}
+}
+
+func statefulSetWithPodLabels(name string, lbls map[string]string) appsv1.StatefulSet {
+	return appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: appsv1.StatefulSetSpec{
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: lbls},
+			},
+		},
+	}
+}
@@ -0,0 +1,135 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// containerIndex matches the index of a container in the path of a violation.
+var containerIndex = regexp.MustCompile(`^spec\.template\.spec\.containers\[(\d+)\]`)
+
+// NewViolations returns the violations of the updated resource which the
+// resource didn't have before the update. Violations are compared by their
+// type, field and detail. A violation the resource already had is only
+// ignored when its value stayed the same or moved towards the boundary of the
+// policy, a replica count which is lowered further below the minimum is a new
+// violation. The violations of a container are matched by the name of the
+// container in the given pod specs, so reordering the containers doesn't
+// change which violations are new.
+func NewViolations(el, old field.ErrorList, podSpec, oldPodSpec v1.PodSpec) field.ErrorList {
+	type violation struct {
+		errorType field.ErrorType
+		field     string
+		detail    string
+	}
+
+	existing := map[violation][]*field.Error{}
+	for _, err := range old {
+		key := violation{err.Type, containerField(err.Field, oldPodSpec), err.Detail}
+		existing[key] = append(existing[key], err)
+	}
+
+	violations := field.ErrorList{}
+	for _, err := range el {
+		key := violation{err.Type, containerField(err.Field, podSpec), err.Detail}
+
+		known := false
+		for _, oldErr := range existing[key] {
+			if !worsens(err, oldErr) {
+				known = true
+				break
+			}
+		}
+
+		if !known {
+			violations = append(violations, err)
+		}
+	}
+
+	return violations
+}
+
+// containerField replaces the index of a container in the field of a
+// violation with the name of the container.
+func containerField(path string, podSpec v1.PodSpec) string {
+	match := containerIndex.FindStringSubmatch(path)
+	if match == nil {
+		return path
+	}
+
+	i, err := strconv.Atoi(match[1])
+	if err != nil || i >= len(podSpec.Containers) {
+		return path
+	}
+
+	return fmt.Sprintf("spec.template.spec.containers[%s]%s", podSpec.Containers[i].Name, path[len(match[0]):])
+}
+
+// worsens checks if the value of a violation moved further away from the
+// boundary of the policy than the value of the same violation before the
+// update. Values which can't be compared should stay the same.
+func worsens(err, old *field.Error) bool {
+	if reflect.DeepEqual(err.BadValue, old.BadValue) {
+		return false
+	}
+
+	cmp, ok := compareValues(err.BadValue, old.BadValue)
+	if !ok {
+		return true
+	}
+
+	switch {
+	case strings.Contains(err.Detail, "should be at least"):
+		return cmp < 0
+	case strings.Contains(err.Detail, "should be at most"):
+		return cmp > 0
+	}
+
+	return true
+}
+
+// compareValues compares two numeric values, quantities or percentages. It
+// returns false when the values can't be compared.
+func compareValues(a, b interface{}) (int, bool) {
+	qa, pa, ok := quantity(a)
+	if !ok {
+		return 0, false
+	}
+
+	qb, pb, ok := quantity(b)
+	if !ok || pa != pb {
+		return 0, false
+	}
+
+	return qa.Cmp(qb), true
+}
+
+// quantity converts the value of a violation to a quantity, it reports if the
+// value is a percentage.
+func quantity(value interface{}) (resource.Quantity, bool, bool) {
+	switch v := value.(type) {
+	case int:
+		return *resource.NewQuantity(int64(v), resource.DecimalSI), false, true
+	case int32:
+		return *resource.NewQuantity(int64(v), resource.DecimalSI), false, true
+	case int64:
+		return *resource.NewQuantity(v, resource.DecimalSI), false, true
+	case *int32:
+		if v != nil {
+			return *resource.NewQuantity(int64(*v), resource.DecimalSI), false, true
+		}
+	case string:
+		percentage := strings.HasSuffix(v, "%")
+		q, err := resource.ParseQuantity(strings.TrimSuffix(v, "%"))
+		return q, percentage, err == nil
+	}
+
+	return resource.Quantity{}, false, false
+}
@@ -0,0 +1,119 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1/validation"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestNewViolations(t *testing.T) {
+	hap := v1alpha1.HighAvailabilityPolicy{
+		Spec: v1alpha1.HighAvailabilityPolicySpec{
+			Replicas: &v1alpha1.HighAvailabilityPolicyReplicas{
+				Minimum: 3,
+				Maximum: ptrInt32(10),
+			},
+		},
+	}
+
+	replicasPath := field.NewPath("spec").Child("replicas")
+	old := appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: ptrInt32(2)}}
+
+	tcs := map[string]struct {
+		replicas *int32
+		errs     []*field.Error
+	}{
+		"with an unrelated update": {
+			replicas: ptrInt32(2),
+		},
+		"with an update which reduces the violation": {
+			replicas: ptrInt32(3),
+		},
+		"with an update which worsens an existing violation": {
+			replicas: ptrInt32(1),
+			errs: []*field.Error{
+				field.Invalid(replicasPath, ptrInt32(1), "should be at least 3"),
+			},
+		},
+		"with an update which scales down to zero": {
+			replicas: ptrInt32(0),
+			errs: []*field.Error{
+				field.Invalid(replicasPath, ptrInt32(0), "should be at least 3"),
+			},
+		},
+		"with an update which introduces a violation": {
+			replicas: ptrInt32(11),
+			errs: []*field.Error{
+				field.Invalid(replicasPath, ptrInt32(11), "should be at most 10"),
+			},
+		},
+	}
+
+	oldErrs := validation.ValidateDeployment(old, hap)
+	if len(oldErrs) != 1 {
+		t.Fatalf("Expected the old deployment to violate the policy, got %v", oldErrs)
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			dpl := appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: tc.replicas}}
+			errs := validation.NewViolations(validation.ValidateDeployment(dpl, hap), oldErrs, dpl.Spec.Template.Spec, old.Spec.Template.Spec)
+			expectErrors(t, tc.errs, errs)
+		})
+	}
+
+	t.Run("with reordered containers", func(t *testing.T) {
+		hap := v1alpha1.HighAvailabilityPolicy{
+			Spec: v1alpha1.HighAvailabilityPolicySpec{
+				Resources: &v1alpha1.HighAvailabilityPolicyResourceRequirements{
+					Requests: v1alpha1.ResourceList{
+						v1.ResourceCPU: true,
+					},
+				},
+			},
+		}
+
+		app := v1.Container{Name: "app"}
+		sidecar := v1.Container{
+			Name: "sidecar",
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")},
+			},
+		}
+
+		old := deploymentWithContainers(app, sidecar)
+		oldErrs := validation.ValidateDeployment(old, hap)
+		if len(oldErrs) != 1 {
+			t.Fatalf("Expected the old deployment to violate the policy, got %v", oldErrs)
+		}
+
+		dpl := deploymentWithContainers(sidecar, app)
+		errs := validation.NewViolations(validation.ValidateDeployment(dpl, hap), oldErrs, dpl.Spec.Template.Spec, old.Spec.Template.Spec)
+		expectErrors(t, nil, errs)
+
+		// the sidecar didn't violate the policy before, so the violation
+		// at the index of the old violation is new.
+		sidecar.Resources = v1.ResourceRequirements{}
+		dpl = deploymentWithContainers(app, sidecar)
+		errs = validation.NewViolations(validation.ValidateDeployment(dpl, hap), oldErrs, dpl.Spec.Template.Spec, old.Spec.Template.Spec)
+		if len(errs) != 1 || errs[0].Field != "spec.template.spec.containers[1].resources.requests.cpu" {
+			t.Errorf("Expected a new violation for the sidecar, got %v", errs)
+		}
+	})
+}
+
+func deploymentWithContainers(containers ...v1.Container) appsv1.Deployment {
+	return appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{Containers: containers},
+			},
+		},
+	}
+}
@@ -27,16 +27,128 @@
 package v1alpha1
 
 import (
+	core_v1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Bounds) DeepCopyInto(out *Bounds) {
+	*out = *in
+	if in.Minimum != nil {
+		in, out := &in.Minimum, &out.Minimum
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Maximum != nil {
+		in, out := &in.Maximum, &out.Maximum
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bounds.
+func (in *Bounds) DeepCopy() *Bounds {
+	if in == nil {
+		return nil
+	}
+	out := new(Bounds)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterHighAvailabilityPolicy) DeepCopyInto(out *ClusterHighAvailabilityPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHighAvailabilityPolicy.
+func (in *ClusterHighAvailabilityPolicy) DeepCopy() *ClusterHighAvailabilityPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterHighAvailabilityPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterHighAvailabilityPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterHighAvailabilityPolicyList) DeepCopyInto(out *ClusterHighAvailabilityPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterHighAvailabilityPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHighAvailabilityPolicyList.
+func (in *ClusterHighAvailabilityPolicyList) DeepCopy() *ClusterHighAvailabilityPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterHighAvailabilityPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterHighAvailabilityPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterHighAvailabilityPolicySpec) DeepCopyInto(out *ClusterHighAvailabilityPolicySpec) {
+	*out = *in
+	in.HighAvailabilityPolicySpec.DeepCopyInto(&out.HighAvailabilityPolicySpec)
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHighAvailabilityPolicySpec.
+func (in *ClusterHighAvailabilityPolicySpec) DeepCopy() *ClusterHighAvailabilityPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterHighAvailabilityPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HighAvailabilityPolicy) DeepCopyInto(out *HighAvailabilityPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -58,6 +170,193 @@ func (in *HighAvailabilityPolicy) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilityPolicyCondition) DeepCopyInto(out *HighAvailabilityPolicyCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilityPolicyCondition.
+func (in *HighAvailabilityPolicyCondition) DeepCopy() *HighAvailabilityPolicyCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilityPolicyCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilityPolicyDaemonSet) DeepCopyInto(out *HighAvailabilityPolicyDaemonSet) {
+	*out = *in
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(HighAvailabilityPolicyDaemonSetUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilityPolicyDaemonSet.
+func (in *HighAvailabilityPolicyDaemonSet) DeepCopy() *HighAvailabilityPolicyDaemonSet {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilityPolicyDaemonSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilityPolicyDaemonSetRollingUpdate) DeepCopyInto(out *HighAvailabilityPolicyDaemonSetRollingUpdate) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilityPolicyDaemonSetRollingUpdate.
+func (in *HighAvailabilityPolicyDaemonSetRollingUpdate) DeepCopy() *HighAvailabilityPolicyDaemonSetRollingUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilityPolicyDaemonSetRollingUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilityPolicyDaemonSetUpdateStrategy) DeepCopyInto(out *HighAvailabilityPolicyDaemonSetUpdateStrategy) {
+	*out = *in
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(HighAvailabilityPolicyDaemonSetRollingUpdate)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilityPolicyDaemonSetUpdateStrategy.
+func (in *HighAvailabilityPolicyDaemonSetUpdateStrategy) DeepCopy() *HighAvailabilityPolicyDaemonSetUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilityPolicyDaemonSetUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilityPolicyDisruptions) DeepCopyInto(out *HighAvailabilityPolicyDisruptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilityPolicyDisruptions.
+func (in *HighAvailabilityPolicyDisruptions) DeepCopy() *HighAvailabilityPolicyDisruptions {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilityPolicyDisruptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilityPolicyException) DeepCopyInto(out *HighAvailabilityPolicyException) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilityPolicyException.
+func (in *HighAvailabilityPolicyException) DeepCopy() *HighAvailabilityPolicyException {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilityPolicyException)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HighAvailabilityPolicyException) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilityPolicyExceptionList) DeepCopyInto(out *HighAvailabilityPolicyExceptionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HighAvailabilityPolicyException, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilityPolicyExceptionList.
+func (in *HighAvailabilityPolicyExceptionList) DeepCopy() *HighAvailabilityPolicyExceptionList {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilityPolicyExceptionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HighAvailabilityPolicyExceptionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilityPolicyExceptionSpec) DeepCopyInto(out *HighAvailabilityPolicyExceptionSpec) {
+	*out = *in
+	out.Policy = in.Policy
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sections != nil {
+		in, out := &in.Sections, &out.Sections
+		*out = make([]Section, len(*in))
+		copy(*out, *in)
+	}
+	if in.Expires != nil {
+		in, out := &in.Expires, &out.Expires
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilityPolicyExceptionSpec.
+func (in *HighAvailabilityPolicyExceptionSpec) DeepCopy() *HighAvailabilityPolicyExceptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilityPolicyExceptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HighAvailabilityPolicyList) DeepCopyInto(out *HighAvailabilityPolicyList) {
 	*out = *in
@@ -91,9 +390,103 @@ func (in *HighAvailabilityPolicyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilityPolicyPodAntiAffinity) DeepCopyInto(out *HighAvailabilityPolicyPodAntiAffinity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilityPolicyPodAntiAffinity.
+func (in *HighAvailabilityPolicyPodAntiAffinity) DeepCopy() *HighAvailabilityPolicyPodAntiAffinity {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilityPolicyPodAntiAffinity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilityPolicyProbe) DeepCopyInto(out *HighAvailabilityPolicyProbe) {
+	*out = *in
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
+		*out = new(Bounds)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(Bounds)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(Bounds)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilityPolicyProbe.
+func (in *HighAvailabilityPolicyProbe) DeepCopy() *HighAvailabilityPolicyProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilityPolicyProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilityPolicyProbes) DeepCopyInto(out *HighAvailabilityPolicyProbes) {
+	*out = *in
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(HighAvailabilityPolicyProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Liveness != nil {
+		in, out := &in.Liveness, &out.Liveness
+		*out = new(HighAvailabilityPolicyProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilityPolicyProbes.
+func (in *HighAvailabilityPolicyProbes) DeepCopy() *HighAvailabilityPolicyProbes {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilityPolicyProbes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilityPolicyReference) DeepCopyInto(out *HighAvailabilityPolicyReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilityPolicyReference.
+func (in *HighAvailabilityPolicyReference) DeepCopy() *HighAvailabilityPolicyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilityPolicyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HighAvailabilityPolicyReplicas) DeepCopyInto(out *HighAvailabilityPolicyReplicas) {
 	*out = *in
+	if in.Maximum != nil {
+		in, out := &in.Maximum, &out.Maximum
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -124,6 +517,34 @@ func (in *HighAvailabilityPolicyResourceRequirements) DeepCopyInto(out *HighAvai
 			(*out)[key] = val
 		}
 	}
+	if in.MinRequests != nil {
+		in, out := &in.MinRequests, &out.MinRequests
+		*out = make(core_v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MaxRequests != nil {
+		in, out := &in.MaxRequests, &out.MaxRequests
+		*out = make(core_v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MinLimits != nil {
+		in, out := &in.MinLimits, &out.MinLimits
+		*out = make(core_v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MaxLimits != nil {
+		in, out := &in.MaxLimits, &out.MaxLimits
+		*out = make(core_v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 	return
 }
 
@@ -155,6 +576,48 @@ func (in *HighAvailabilityPolicyRollingUpdate) DeepCopy() *HighAvailabilityPolic
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilityPolicyScheduling) DeepCopyInto(out *HighAvailabilityPolicyScheduling) {
+	*out = *in
+	if in.PodAntiAffinity != nil {
+		in, out := &in.PodAntiAffinity, &out.PodAntiAffinity
+		*out = new(HighAvailabilityPolicyPodAntiAffinity)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilityPolicyScheduling.
+func (in *HighAvailabilityPolicyScheduling) DeepCopy() *HighAvailabilityPolicyScheduling {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilityPolicyScheduling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilityPolicyShutdown) DeepCopyInto(out *HighAvailabilityPolicyShutdown) {
+	*out = *in
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(Bounds)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilityPolicyShutdown.
+func (in *HighAvailabilityPolicyShutdown) DeepCopy() *HighAvailabilityPolicyShutdown {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilityPolicyShutdown)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HighAvailabilityPolicySpec) DeepCopyInto(out *HighAvailabilityPolicySpec) {
 	*out = *in
@@ -166,7 +629,7 @@ func (in *HighAvailabilityPolicySpec) DeepCopyInto(out *HighAvailabilityPolicySp
 	if in.Replicas != nil {
 		in, out := &in.Replicas, &out.Replicas
 		*out = new(HighAvailabilityPolicyReplicas)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Strategy != nil {
 		in, out := &in.Strategy, &out.Strategy
@@ -178,6 +641,36 @@ func (in *HighAvailabilityPolicySpec) DeepCopyInto(out *HighAvailabilityPolicySp
 		*out = new(HighAvailabilityPolicyResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Disruptions != nil {
+		in, out := &in.Disruptions, &out.Disruptions
+		*out = new(HighAvailabilityPolicyDisruptions)
+		**out = **in
+	}
+	if in.StatefulSet != nil {
+		in, out := &in.StatefulSet, &out.StatefulSet
+		*out = new(HighAvailabilityPolicyStatefulSet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DaemonSet != nil {
+		in, out := &in.DaemonSet, &out.DaemonSet
+		*out = new(HighAvailabilityPolicyDaemonSet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(HighAvailabilityPolicyScheduling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(HighAvailabilityPolicyProbes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Shutdown != nil {
+		in, out := &in.Shutdown, &out.Shutdown
+		*out = new(HighAvailabilityPolicyShutdown)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -191,6 +684,97 @@ func (in *HighAvailabilityPolicySpec) DeepCopy() *HighAvailabilityPolicySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilityPolicyStatefulSet) DeepCopyInto(out *HighAvailabilityPolicyStatefulSet) {
+	*out = *in
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(HighAvailabilityPolicyStatefulSetUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilityPolicyStatefulSet.
+func (in *HighAvailabilityPolicyStatefulSet) DeepCopy() *HighAvailabilityPolicyStatefulSet {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilityPolicyStatefulSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilityPolicyStatefulSetRollingUpdate) DeepCopyInto(out *HighAvailabilityPolicyStatefulSetRollingUpdate) {
+	*out = *in
+	if in.MaxPartition != nil {
+		in, out := &in.MaxPartition, &out.MaxPartition
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilityPolicyStatefulSetRollingUpdate.
+func (in *HighAvailabilityPolicyStatefulSetRollingUpdate) DeepCopy() *HighAvailabilityPolicyStatefulSetRollingUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilityPolicyStatefulSetRollingUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilityPolicyStatefulSetUpdateStrategy) DeepCopyInto(out *HighAvailabilityPolicyStatefulSetUpdateStrategy) {
+	*out = *in
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(HighAvailabilityPolicyStatefulSetRollingUpdate)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilityPolicyStatefulSetUpdateStrategy.
+func (in *HighAvailabilityPolicyStatefulSetUpdateStrategy) DeepCopy() *HighAvailabilityPolicyStatefulSetUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilityPolicyStatefulSetUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighAvailabilityPolicyStatus) DeepCopyInto(out *HighAvailabilityPolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]HighAvailabilityPolicyCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Violations != nil {
+		in, out := &in.Violations, &out.Violations
+		*out = make([]core_v1.ObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighAvailabilityPolicyStatus.
+func (in *HighAvailabilityPolicyStatus) DeepCopy() *HighAvailabilityPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HighAvailabilityPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HighAvailabilityPolicyStrategy) DeepCopyInto(out *HighAvailabilityPolicyStrategy) {
 	*out = *in
@@ -199,6 +783,21 @@ func (in *HighAvailabilityPolicyStrategy) DeepCopyInto(out *HighAvailabilityPoli
 		*out = new(HighAvailabilityPolicyRollingUpdate)
 		**out = **in
 	}
+	if in.MinReadySeconds != nil {
+		in, out := &in.MinReadySeconds, &out.MinReadySeconds
+		*out = new(Bounds)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(Bounds)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(Bounds)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"time"
+
+	"github.com/jelmersnoeck/barbossa/internal/audit"
+	"github.com/jelmersnoeck/barbossa/pkg/client/generated/clientset/versioned"
+	"github.com/jelmersnoeck/barbossa/pkg/client/generated/informers/externalversions"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+type auditOptions struct {
+	kubeconfig   string
+	master       string
+	workers      int
+	resyncPeriod time.Duration
+}
+
+// newAuditCommand creates the command which runs the audit controller. It
+// reports the existing workloads which violate their policy.
+func newAuditCommand(stopCh <-chan struct{}) *cobra.Command {
+	opts := &auditOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Continuously validate the existing workloads against their policy",
+		RunE: func(c *cobra.Command, args []string) error {
+			return runAudit(opts, stopCh)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.kubeconfig, "kubeconfig", "", "Path to a kubeconfig, only required when running out of cluster.")
+	cmd.Flags().StringVar(&opts.master, "master", "", "The address of the Kubernetes API server, overrides the value in the kubeconfig.")
+	cmd.Flags().IntVar(&opts.workers, "workers", 2, "The number of workloads which are audited concurrently.")
+	cmd.Flags().DurationVar(&opts.resyncPeriod, "resync-period", 10*time.Minute, "The interval in which all workloads are audited again.")
+
+	return cmd
+}
+
+func runAudit(opts *auditOptions, stopCh <-chan struct{}) error {
+	cfg, err := clientcmd.BuildConfigFromFlags(opts.master, opts.kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	crdClient, err := versioned.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	crdInformers := externalversions.NewSharedInformerFactory(crdClient, opts.resyncPeriod)
+	kubeInformers := informers.NewSharedInformerFactory(kubeClient, opts.resyncPeriod)
+	ctrl := audit.NewController(kubeClient, crdClient, crdInformers, kubeInformers)
+
+	crdInformers.Start(stopCh)
+	kubeInformers.Start(stopCh)
+
+	return ctrl.Run(opts.workers, stopCh)
+}
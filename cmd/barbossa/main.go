@@ -1,13 +1,36 @@
 package main
 
 import (
+	"flag"
+	"os"
+
 	"github.com/jelmersnoeck/barbossa/internal/webhooks"
 
-	"github.com/openshift/generic-admission-server/pkg/cmd"
+	"github.com/golang/glog"
+	"github.com/openshift/generic-admission-server/pkg/cmd/server"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/apiserver/pkg/util/logs"
 )
 
 func main() {
-	cmd.RunAdmissionServer(
-		&webhooks.HighAvailabilityAdmissionHook{},
+	logs.InitLogs()
+	defer logs.FlushLogs()
+
+	stopCh := genericapiserver.SetupSignalHandler()
+
+	// running barbossa without a subcommand starts the admission server, the
+	// subcommands provide the other modes of the binary. The admission hooks
+	// share their informers so every resource is only watched once.
+	informers := &webhooks.Informers{}
+	cmd := server.NewCommandStartAdmissionServer(os.Stdout, os.Stderr, stopCh,
+		&webhooks.HighAvailabilityAdmissionHook{Informers: informers},
+		&webhooks.HighAvailabilityDefaultsHook{Informers: informers},
 	)
+	cmd.AddCommand(newAuditCommand(stopCh))
+	cmd.AddCommand(newValidateCommand())
+	cmd.PersistentFlags().AddGoFlagSet(flag.CommandLine)
+
+	if err := cmd.Execute(); err != nil {
+		glog.Fatal(err)
+	}
 }
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jelmersnoeck/barbossa/internal/manifests"
+	"github.com/jelmersnoeck/barbossa/internal/report"
+
+	"github.com/spf13/cobra"
+)
+
+// The exit codes of the validate command.
+const (
+	exitValid     = 0
+	exitViolation = 1
+	exitError     = 2
+)
+
+type validateOptions struct {
+	filenames []string
+	namespace string
+	output    string
+}
+
+// newValidateCommand creates the command which validates manifests against the
+// policies in the given manifests, without a connection to a cluster.
+func newValidateCommand() *cobra.Command {
+	opts := &validateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "validate -f FILENAME [-f FILENAME...]",
+		Short: "Validate workload manifests against the policies in the given manifests",
+		Long: `Validate workload manifests against the policies in the given manifests.
+
+The policies are selected the same way the webhook selects them. The command
+exits with 1 when a workload violates its policy and with 2 when the manifests
+can't be read.`,
+		Run: func(c *cobra.Command, args []string) {
+			os.Exit(runValidate(opts, os.Stdin, os.Stdout, os.Stderr))
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.filenames, "filename", "f", nil, "The files or directories which contain the manifests, use - to read from stdin.")
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "default", "The namespace of the objects which don't specify one.")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", string(report.FormatText), fmt.Sprintf("The format of the report, one of %v.", report.Formats))
+
+	return cmd
+}
+
+func runValidate(opts *validateOptions, stdin io.Reader, out, errOut io.Writer) int {
+	if len(opts.filenames) == 0 {
+		fmt.Fprintln(errOut, "At least one filename is required")
+		return exitError
+	}
+
+	set := manifests.NewSet(opts.namespace)
+	for _, filename := range opts.filenames {
+		var err error
+		if filename == "-" {
+			err = set.Read("stdin", stdin)
+		} else {
+			err = set.ReadPath(filename)
+		}
+
+		if err != nil {
+			fmt.Fprintf(errOut, "Could not read the manifests: %s\n", err)
+			return exitError
+		}
+	}
+
+	results, err := set.Validate()
+	if err != nil {
+		fmt.Fprintf(errOut, "Could not validate the manifests: %s\n", err)
+		return exitError
+	}
+
+	if err := report.Write(out, report.Format(opts.output), results); err != nil {
+		fmt.Fprintf(errOut, "Could not write the report: %s\n", err)
+		return exitError
+	}
+
+	if report.Violating(results) > 0 {
+		return exitViolation
+	}
+
+	return exitValid
+}
@@ -0,0 +1,757 @@
+// Package audit continuously validates the existing workloads in the cluster
+// against the policy which selects them. The admission hooks only see
+// workloads when they're created or updated, the audit controller reports the
+// workloads which were created before a policy was added or tightened.
+package audit
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1/validation"
+	"github.com/jelmersnoeck/barbossa/internal/policy"
+	"github.com/jelmersnoeck/barbossa/internal/report"
+	"github.com/jelmersnoeck/barbossa/internal/workloads"
+	"github.com/jelmersnoeck/barbossa/pkg/client/generated/clientset/versioned"
+	"github.com/jelmersnoeck/barbossa/pkg/client/generated/informers/externalversions"
+	listers "github.com/jelmersnoeck/barbossa/pkg/client/generated/listers/barbossa/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	autoscalinglisters "k8s.io/client-go/listers/autoscaling/v1"
+	policylisters "k8s.io/client-go/listers/policy/v1beta1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// ComponentName is the source of the events recorded by the controller.
+	ComponentName = "barbossa-audit"
+
+	// ViolationReason is the reason of the events recorded for workloads
+	// which violate the policy that selects them.
+	ViolationReason = "PolicyViolation"
+)
+
+// Controller audits the Deployments, StatefulSets and DaemonSets in the
+// cluster. Every workload is validated when it changes, when a policy,
+// exception, PodDisruptionBudget or autoscaler which applies to it changes,
+// when the labels of its namespace change and on every resync of the
+// informers. Violations are logged and recorded as Warning events on the
+// workload. The results are aggregated in the status of the policies.
+type Controller struct {
+	crdClient  versioned.Interface
+	policies   *policy.Cache
+	hapLister  listers.HighAvailabilityPolicyLister
+	chapLister listers.ClusterHighAvailabilityPolicyLister
+	dplLister  appslisters.DeploymentLister
+	stsLister  appslisters.StatefulSetLister
+	dsLister   appslisters.DaemonSetLister
+	pdbLister  policylisters.PodDisruptionBudgetLister
+	hpaLister  autoscalinglisters.HorizontalPodAutoscalerLister
+	synced     []cache.InformerSynced
+
+	queue       workqueue.RateLimitingInterface
+	statusQueue workqueue.RateLimitingInterface
+	recorder    record.EventRecorder
+
+	// results keeps the latest audit result of every workload by its key,
+	// they're used to calculate the status of the policies.
+	resultsLock sync.Mutex
+	results     map[workloadKey]policyResult
+
+	// pending keeps the workloads which haven't been audited since the
+	// controller started. The status of the policies is only published once
+	// all of them have been audited, audited is closed when that happens.
+	pendingLock sync.Mutex
+	pending     map[workloadKey]struct{}
+	audited     chan struct{}
+}
+
+// workloadKey identifies a workload which is audited.
+type workloadKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// policyKind is the Kind of a namespaced policy.
+const policyKind = "HighAvailabilityPolicy"
+
+// policyKey identifies the namespaced or cluster policy a workload is
+// validated against.
+type policyKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+type policyResult struct {
+	policy policyKey
+	Result
+}
+
+// NewController creates a new audit controller which uses the informers of the
+// given factories. The factories should be started before the controller is
+// run.
+func NewController(kubeClient kubernetes.Interface, crdClient versioned.Interface, crdInformers externalversions.SharedInformerFactory, kubeInformers informers.SharedInformerFactory) *Controller {
+	hapInformer := crdInformers.Barbossa().V1alpha1().HighAvailabilityPolicies()
+	chapInformer := crdInformers.Barbossa().V1alpha1().ClusterHighAvailabilityPolicies()
+	excInformer := crdInformers.Barbossa().V1alpha1().HighAvailabilityPolicyExceptions()
+	nsInformer := kubeInformers.Core().V1().Namespaces()
+	dplInformer := kubeInformers.Apps().V1().Deployments()
+	stsInformer := kubeInformers.Apps().V1().StatefulSets()
+	dsInformer := kubeInformers.Apps().V1().DaemonSets()
+	pdbInformer := kubeInformers.Policy().V1beta1().PodDisruptionBudgets()
+	hpaInformer := kubeInformers.Autoscaling().V1().HorizontalPodAutoscalers()
+
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+
+	c := &Controller{
+		crdClient:   crdClient,
+		policies:    policy.NewCache(kubeClient, crdInformers, kubeInformers),
+		hapLister:   hapInformer.Lister(),
+		chapLister:  chapInformer.Lister(),
+		dplLister:   dplInformer.Lister(),
+		stsLister:   stsInformer.Lister(),
+		dsLister:    dsInformer.Lister(),
+		pdbLister:   pdbInformer.Lister(),
+		hpaLister:   hpaInformer.Lister(),
+		queue:       workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "audit"),
+		statusQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "audit-status"),
+		recorder:    broadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: ComponentName}),
+		results:     map[workloadKey]policyResult{},
+		audited:     make(chan struct{}),
+	}
+
+	c.synced = []cache.InformerSynced{
+		c.policies.HasSynced,
+		dplInformer.Informer().HasSynced,
+		stsInformer.Informer().HasSynced,
+		dsInformer.Informer().HasSynced,
+		pdbInformer.Informer().HasSynced,
+		hpaInformer.Informer().HasSynced,
+	}
+
+	workloadHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueWorkload,
+		UpdateFunc: func(_, obj interface{}) {
+			c.enqueueWorkload(obj)
+		},
+		DeleteFunc: c.enqueueWorkload,
+	}
+	dplInformer.Informer().AddEventHandler(workloadHandler)
+	stsInformer.Informer().AddEventHandler(workloadHandler)
+	dsInformer.Informer().AddEventHandler(workloadHandler)
+
+	pdbInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueBudget,
+		UpdateFunc: func(_, obj interface{}) {
+			c.enqueueBudget(obj)
+		},
+		DeleteFunc: c.enqueueBudget,
+	})
+
+	hpaInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueAutoscaler,
+		UpdateFunc: func(old, obj interface{}) {
+			// the autoscaler can target a different Deployment after the
+			// update, both of them need to be validated again.
+			c.enqueueAutoscaler(old)
+			c.enqueueAutoscaler(obj)
+		},
+		DeleteFunc: c.enqueueAutoscaler,
+	})
+
+	policyHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueuePolicy,
+		UpdateFunc: func(old, obj interface{}) {
+			// updating the status of a policy doesn't change which
+			// workloads violate it.
+			if specChanged(old, obj) {
+				c.enqueuePolicy(obj)
+			}
+		},
+		DeleteFunc: c.enqueuePolicy,
+	}
+	hapInformer.Informer().AddEventHandler(policyHandler)
+	chapInformer.Informer().AddEventHandler(policyHandler)
+
+	excInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueException,
+		UpdateFunc: func(_, obj interface{}) {
+			c.enqueueException(obj)
+		},
+		DeleteFunc: c.enqueueException,
+	})
+
+	nsInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, obj interface{}) {
+			// cluster policies select workloads by the labels of their
+			// namespace.
+			oldNs, ok := old.(*v1.Namespace)
+			ns, nsOk := obj.(*v1.Namespace)
+			if ok && nsOk && !equality.Semantic.DeepEqual(oldNs.Labels, ns.Labels) {
+				c.enqueueNamespace(ns.Name)
+			}
+		},
+	})
+
+	return c
+}
+
+// Run waits for the caches to be synced and starts the given number of
+// workers. The status workers are only started once every workload in the
+// cache has been audited, so the status of a policy isn't calculated from a
+// partial audit. It blocks until the stop channel is closed.
+func (c *Controller) Run(workers int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+	defer c.statusQueue.ShutDown()
+
+	log.Printf("Starting the audit controller")
+	if !cache.WaitForCacheSync(stopCh, c.synced...) {
+		return fmt.Errorf("could not sync the audit caches")
+	}
+
+	pending, err := c.workloadKeys()
+	if err != nil {
+		return err
+	}
+
+	c.pendingLock.Lock()
+	c.pending = pending
+	if len(pending) == 0 {
+		c.pending = nil
+		close(c.audited)
+	}
+	c.pendingLock.Unlock()
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	log.Printf("Waiting for the initial audit to finish")
+	select {
+	case <-c.audited:
+	case <-stopCh:
+		log.Printf("Stopping the audit controller")
+		return nil
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.runStatusWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	log.Printf("Stopping the audit controller")
+	return nil
+}
+
+func (c *Controller) runWorker() {
+	for c.processNextItem() {
+	}
+}
+
+func (c *Controller) processNextItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	wk := key.(workloadKey)
+	if err := c.audit(wk); err != nil {
+		utilruntime.HandleError(fmt.Errorf("could not audit %s %s/%s: %s", wk.kind, wk.namespace, wk.name, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+
+	c.queue.Forget(key)
+	c.markAudited(wk)
+	return true
+}
+
+// workloadKeys returns the keys of all the workloads in the cache.
+func (c *Controller) workloadKeys() (map[workloadKey]struct{}, error) {
+	keys := map[workloadKey]struct{}{}
+
+	dpls, err := c.dplLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dpl := range dpls {
+		keys[workloadKey{kind: workloads.DeploymentKind, namespace: dpl.Namespace, name: dpl.Name}] = struct{}{}
+	}
+
+	stss, err := c.stsLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sts := range stss {
+		keys[workloadKey{kind: workloads.StatefulSetKind, namespace: sts.Namespace, name: sts.Name}] = struct{}{}
+	}
+
+	dss, err := c.dsLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ds := range dss {
+		keys[workloadKey{kind: workloads.DaemonSetKind, namespace: ds.Namespace, name: ds.Name}] = struct{}{}
+	}
+
+	return keys, nil
+}
+
+// markAudited removes a workload from the pending workloads and closes the
+// audited channel once none are left.
+func (c *Controller) markAudited(key workloadKey) {
+	c.pendingLock.Lock()
+	defer c.pendingLock.Unlock()
+
+	if c.pending == nil {
+		return
+	}
+
+	delete(c.pending, key)
+	if len(c.pending) == 0 {
+		c.pending = nil
+		close(c.audited)
+	}
+}
+
+// audit validates the workload with the given key against the policy which
+// selects it.
+func (c *Controller) audit(key workloadKey) error {
+	obj, meta, err := c.workload(key)
+	if kerrors.IsNotFound(err) {
+		// the workload has been removed, there's nothing to audit.
+		c.forgetResult(key)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	hap, err := c.policies.Select(meta)
+	if err != nil {
+		return err
+	}
+
+	// no policy selects this workload, there's nothing to audit.
+	if hap == nil {
+		c.forgetResult(key)
+		return nil
+	}
+
+	hap, exemption, el := policy.Exempt(meta, hap, time.Now())
+	if exemption != nil && !exemption.Expired(time.Now()) {
+		log.Printf("%s %s:%s is exempted from %s of %s", key.kind, meta.Namespace, meta.Name, exemption, policy.Describe(hap))
+	}
+
+	violations, err := c.violations(obj, hap)
+	if err != nil {
+		return err
+	}
+
+	el = append(el, violations...)
+	if err := el.ToAggregate(); err != nil {
+		log.Printf("%s %s:%s violates %s: %s", key.kind, meta.Namespace, meta.Name, policy.Describe(hap), err)
+		c.recorder.Eventf(obj, v1.EventTypeWarning, ViolationReason, "%s violates %s: %s", key.kind, policy.Describe(hap), err)
+	}
+
+	c.storeResult(key, policyResult{
+		policy: keyForPolicy(hap),
+		Result: Result{
+			Generation: hap.Generation,
+			Result: report.Result{
+				Workload: v1.ObjectReference{
+					Kind:       key.kind,
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+					Namespace:  meta.Namespace,
+					Name:       meta.Name,
+					UID:        meta.UID,
+				},
+				Policy: policy.Describe(hap),
+				Errors: el,
+			},
+		},
+	})
+
+	return nil
+}
+
+// workload returns the workload with the given key from the cache.
+func (c *Controller) workload(key workloadKey) (runtime.Object, metav1.ObjectMeta, error) {
+	switch key.kind {
+	case workloads.DeploymentKind:
+		dpl, err := c.dplLister.Deployments(key.namespace).Get(key.name)
+		if err != nil {
+			return nil, metav1.ObjectMeta{}, err
+		}
+
+		return dpl, dpl.ObjectMeta, nil
+	case workloads.StatefulSetKind:
+		sts, err := c.stsLister.StatefulSets(key.namespace).Get(key.name)
+		if err != nil {
+			return nil, metav1.ObjectMeta{}, err
+		}
+
+		return sts, sts.ObjectMeta, nil
+	case workloads.DaemonSetKind:
+		ds, err := c.dsLister.DaemonSets(key.namespace).Get(key.name)
+		if err != nil {
+			return nil, metav1.ObjectMeta{}, err
+		}
+
+		return ds, ds.ObjectMeta, nil
+	}
+
+	return nil, metav1.ObjectMeta{}, fmt.Errorf("unsupported kind %s", key.kind)
+}
+
+// violations validates the workload against the given policy.
+func (c *Controller) violations(obj runtime.Object, hap *v1alpha1.HighAvailabilityPolicy) (field.ErrorList, error) {
+	switch w := obj.(type) {
+	case *appsv1.Deployment:
+		return c.deploymentViolations(w, hap)
+	case *appsv1.StatefulSet:
+		return validation.ValidateStatefulSet(*w, *hap), nil
+	case *appsv1.DaemonSet:
+		return validation.ValidateDaemonSet(*w, *hap), nil
+	}
+
+	return nil, fmt.Errorf("unsupported workload %T", obj)
+}
+
+// deploymentViolations validates the Deployment, its autoscaler and the
+// PodDisruptionBudgets selecting it against the given policy.
+func (c *Controller) deploymentViolations(dpl *appsv1.Deployment, hap *v1alpha1.HighAvailabilityPolicy) (field.ErrorList, error) {
+	hpas, err := c.hpaLister.HorizontalPodAutoscalers(dpl.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	// when an autoscaler manages the replica count, the bounds of the
+	// autoscaler are validated instead of the replica count itself.
+	var el field.ErrorList
+	if hpa := workloads.Autoscaler(workloads.DeploymentKind, dpl.Name, autoscalers(hpas)); hpa != nil {
+		el = validation.ValidateAutoscaledDeployment(*dpl, *hpa, *hap)
+	} else {
+		el = validation.ValidateDeployment(*dpl, *hap)
+	}
+
+	if hap.Spec.Disruptions != nil {
+		pdbs, err := c.pdbLister.PodDisruptionBudgets(dpl.Namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+
+		dpls, err := c.dplLister.Deployments(dpl.Namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+
+		el = append(el, validation.ValidateDisruptions(*dpl, budgets(pdbs), deployments(dpls), *hap)...)
+	}
+
+	return el, nil
+}
+
+// storeResult stores the audit result of a workload. When the result changes,
+// the status of the policies it's validated against is updated.
+func (c *Controller) storeResult(key workloadKey, result policyResult) {
+	c.resultsLock.Lock()
+	old, ok := c.results[key]
+	c.results[key] = result
+	c.resultsLock.Unlock()
+
+	if ok && equality.Semantic.DeepEqual(old, result) {
+		return
+	}
+
+	if ok && old.policy != result.policy {
+		c.statusQueue.Add(old.policy)
+	}
+
+	c.statusQueue.Add(result.policy)
+}
+
+// forgetResult removes the audit result of a workload which is no longer
+// validated against a policy.
+func (c *Controller) forgetResult(key workloadKey) {
+	c.resultsLock.Lock()
+	old, ok := c.results[key]
+	delete(c.results, key)
+	c.resultsLock.Unlock()
+
+	if ok {
+		c.statusQueue.Add(old.policy)
+	}
+}
+
+func (c *Controller) runStatusWorker() {
+	for c.processNextStatus() {
+	}
+}
+
+func (c *Controller) processNextStatus() bool {
+	key, quit := c.statusQueue.Get()
+	if quit {
+		return false
+	}
+	defer c.statusQueue.Done(key)
+
+	pk := key.(policyKey)
+	if err := c.updateStatus(pk); err != nil {
+		utilruntime.HandleError(fmt.Errorf("could not update the status of %s %s/%s: %s", pk.kind, pk.namespace, pk.name, err))
+		c.statusQueue.AddRateLimited(key)
+		return true
+	}
+
+	c.statusQueue.Forget(key)
+	return true
+}
+
+// updateStatus calculates the status of a policy from the results of the
+// workloads which are validated against it and updates the policy when its
+// status has changed.
+func (c *Controller) updateStatus(pk policyKey) error {
+	results := []Result{}
+
+	c.resultsLock.Lock()
+	for _, result := range c.results {
+		if result.policy == pk {
+			results = append(results, result.Result)
+		}
+	}
+	c.resultsLock.Unlock()
+
+	if pk.kind == policy.ClusterPolicyKind {
+		chap, err := c.chapLister.Get(pk.name)
+		if kerrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		status := PolicyStatus(chap.Generation, chap.Status, results, metav1.Now())
+		if equality.Semantic.DeepEqual(status, chap.Status) {
+			return nil
+		}
+
+		chap = chap.DeepCopy()
+		chap.Status = status
+		_, err = c.crdClient.BarbossaV1alpha1().ClusterHighAvailabilityPolicies().UpdateStatus(chap)
+		return err
+	}
+
+	hap, err := c.hapLister.HighAvailabilityPolicies(pk.namespace).Get(pk.name)
+	if kerrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	status := PolicyStatus(hap.Generation, hap.Status, results, metav1.Now())
+	if equality.Semantic.DeepEqual(status, hap.Status) {
+		return nil
+	}
+
+	hap = hap.DeepCopy()
+	hap.Status = status
+	_, err = c.crdClient.BarbossaV1alpha1().HighAvailabilityPolicies(pk.namespace).UpdateStatus(hap)
+	return err
+}
+
+func keyForPolicy(hap *v1alpha1.HighAvailabilityPolicy) policyKey {
+	if hap.Kind == policy.ClusterPolicyKind {
+		return policyKey{kind: policy.ClusterPolicyKind, name: hap.Name}
+	}
+
+	return policyKey{kind: policyKind, namespace: hap.Namespace, name: hap.Name}
+}
+
+// enqueueWorkload enqueues a Deployment, StatefulSet or DaemonSet.
+func (c *Controller) enqueueWorkload(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	switch w := obj.(type) {
+	case *appsv1.Deployment:
+		c.queue.Add(workloadKey{kind: workloads.DeploymentKind, namespace: w.Namespace, name: w.Name})
+	case *appsv1.StatefulSet:
+		c.queue.Add(workloadKey{kind: workloads.StatefulSetKind, namespace: w.Namespace, name: w.Name})
+	case *appsv1.DaemonSet:
+		c.queue.Add(workloadKey{kind: workloads.DaemonSetKind, namespace: w.Namespace, name: w.Name})
+	}
+}
+
+// enqueueAutoscaler enqueues the Deployment which is scaled by the autoscaler.
+func (c *Controller) enqueueAutoscaler(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	hpa, ok := obj.(*autoscalingv1.HorizontalPodAutoscaler)
+	if !ok || hpa.Spec.ScaleTargetRef.Kind != workloads.DeploymentKind {
+		return
+	}
+
+	c.queue.Add(workloadKey{kind: workloads.DeploymentKind, namespace: hpa.Namespace, name: hpa.Spec.ScaleTargetRef.Name})
+}
+
+// enqueueBudget enqueues the Deployments in the namespace of a
+// PodDisruptionBudget. Both the Deployments the budget selects now and the
+// ones it selected before the change can be affected, as well as the
+// Deployments which share their pods with them.
+func (c *Controller) enqueueBudget(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	pdb, ok := obj.(*policyv1beta1.PodDisruptionBudget)
+	if !ok {
+		return
+	}
+
+	dpls, err := c.dplLister.Deployments(pdb.Namespace).List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	for _, dpl := range dpls {
+		c.enqueueWorkload(dpl)
+	}
+}
+
+// enqueueException enqueues the workloads in the namespace of an exception.
+func (c *Controller) enqueueException(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	if exception, ok := obj.(*v1alpha1.HighAvailabilityPolicyException); ok {
+		c.enqueueNamespace(exception.Namespace)
+	}
+}
+
+// enqueuePolicy enqueues all the workloads a policy can select. Namespaced
+// policies only select workloads within their own namespace, cluster policies
+// don't have a namespace and can select any workload. The status of the
+// policy itself is updated as well so it reflects its latest generation, even
+// when it doesn't select any workloads.
+func (c *Controller) enqueuePolicy(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	switch p := obj.(type) {
+	case *v1alpha1.HighAvailabilityPolicy:
+		c.statusQueue.Add(policyKey{kind: policyKind, namespace: p.Namespace, name: p.Name})
+		c.enqueueNamespace(p.Namespace)
+	case *v1alpha1.ClusterHighAvailabilityPolicy:
+		c.statusQueue.Add(policyKey{kind: policy.ClusterPolicyKind, name: p.Name})
+		c.enqueueNamespace(metav1.NamespaceAll)
+	}
+}
+
+// enqueueNamespace enqueues all the workloads in the given namespace, or in
+// all namespaces when it's empty.
+func (c *Controller) enqueueNamespace(namespace string) {
+	dpls, err := c.dplLister.Deployments(namespace).List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	for _, dpl := range dpls {
+		c.enqueueWorkload(dpl)
+	}
+
+	stss, err := c.stsLister.StatefulSets(namespace).List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	for _, sts := range stss {
+		c.enqueueWorkload(sts)
+	}
+
+	dss, err := c.dsLister.DaemonSets(namespace).List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	for _, ds := range dss {
+		c.enqueueWorkload(ds)
+	}
+}
+
+func budgets(pdbs []*policyv1beta1.PodDisruptionBudget) []policyv1beta1.PodDisruptionBudget {
+	items := make([]policyv1beta1.PodDisruptionBudget, len(pdbs))
+	for i, pdb := range pdbs {
+		items[i] = *pdb
+	}
+
+	return items
+}
+
+func deployments(dpls []*appsv1.Deployment) []appsv1.Deployment {
+	items := make([]appsv1.Deployment, len(dpls))
+	for i, dpl := range dpls {
+		items[i] = *dpl
+	}
+
+	return items
+}
+
+func autoscalers(hpas []*autoscalingv1.HorizontalPodAutoscaler) []autoscalingv1.HorizontalPodAutoscaler {
+	items := make([]autoscalingv1.HorizontalPodAutoscaler, len(hpas))
+	for i, hpa := range hpas {
+		items[i] = *hpa
+	}
+
+	return items
+}
+
+// specChanged checks if the spec of a policy has changed between two
+// versions.
+func specChanged(old, obj interface{}) bool {
+	switch p := obj.(type) {
+	case *v1alpha1.HighAvailabilityPolicy:
+		oldHap, ok := old.(*v1alpha1.HighAvailabilityPolicy)
+		return !ok || !equality.Semantic.DeepEqual(oldHap.Spec, p.Spec)
+	case *v1alpha1.ClusterHighAvailabilityPolicy:
+		oldChap, ok := old.(*v1alpha1.ClusterHighAvailabilityPolicy)
+		return !ok || !equality.Semantic.DeepEqual(oldChap.Spec, p.Spec)
+	}
+
+	return true
+}
@@ -0,0 +1,95 @@
+package audit
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+	"github.com/jelmersnoeck/barbossa/internal/report"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The reasons used for the Compliant condition of a policy.
+const (
+	CompliantReason = "WorkloadsCompliant"
+	ViolatingReason = "WorkloadsViolating"
+)
+
+// Result is the audit result of a workload together with the generation of
+// the policy it was validated against.
+type Result struct {
+	Generation int64
+	report.Result
+}
+
+// PolicyStatus calculates the status of a policy from the results of the
+// workloads which are validated against it, every Deployment, StatefulSet and
+// DaemonSet is counted. The observed generation is only updated once every
+// workload has been validated against the given generation of the policy, the
+// transition time of the Compliant condition only when its status changes.
+func PolicyStatus(generation int64, current v1alpha1.HighAvailabilityPolicyStatus, results []Result, now metav1.Time) v1alpha1.HighAvailabilityPolicyStatus {
+	status := v1alpha1.HighAvailabilityPolicyStatus{
+		ObservedGeneration: generation,
+		MatchedWorkloads:   int32(len(results)),
+	}
+
+	for _, result := range results {
+		if result.Generation != generation {
+			status.ObservedGeneration = current.ObservedGeneration
+			break
+		}
+	}
+
+	violations := []v1.ObjectReference{}
+	for _, result := range results {
+		if len(result.Errors) > 0 {
+			violations = append(violations, result.Workload)
+		}
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].Namespace != violations[j].Namespace {
+			return violations[i].Namespace < violations[j].Namespace
+		}
+
+		if violations[i].Name != violations[j].Name {
+			return violations[i].Name < violations[j].Name
+		}
+
+		return violations[i].Kind < violations[j].Kind
+	})
+
+	status.ViolatingWorkloads = int32(len(violations))
+	if len(violations) > v1alpha1.MaxStatusViolations {
+		violations = violations[:v1alpha1.MaxStatusViolations]
+	}
+
+	if len(violations) > 0 {
+		status.Violations = violations
+	}
+
+	condition := v1alpha1.HighAvailabilityPolicyCondition{
+		Type:               v1alpha1.HighAvailabilityPolicyCompliant,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: now,
+		Reason:             CompliantReason,
+		Message:            fmt.Sprintf("All %d matched workloads comply with the policy", status.MatchedWorkloads),
+	}
+
+	if status.ViolatingWorkloads > 0 {
+		condition.Status = v1.ConditionFalse
+		condition.Reason = ViolatingReason
+		condition.Message = fmt.Sprintf("%d of %d matched workloads violate the policy", status.ViolatingWorkloads, status.MatchedWorkloads)
+	}
+
+	for _, cur := range current.Conditions {
+		if cur.Type == condition.Type && cur.Status == condition.Status {
+			condition.LastTransitionTime = cur.LastTransitionTime
+		}
+	}
+
+	status.Conditions = []v1alpha1.HighAvailabilityPolicyCondition{condition}
+	return status
+}
@@ -0,0 +1,166 @@
+package audit_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+	"github.com/jelmersnoeck/barbossa/internal/audit"
+	"github.com/jelmersnoeck/barbossa/internal/report"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestPolicyStatus(t *testing.T) {
+	then := metav1.NewTime(time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(then.Add(time.Hour))
+
+	t.Run("without any results", func(t *testing.T) {
+		status := audit.PolicyStatus(2, v1alpha1.HighAvailabilityPolicyStatus{}, nil, now)
+
+		if status.ObservedGeneration != 2 {
+			t.Errorf("Expected observed generation 2, got %d", status.ObservedGeneration)
+		}
+
+		if status.MatchedWorkloads != 0 || status.ViolatingWorkloads != 0 {
+			t.Errorf("Expected no matched or violating workloads, got %d and %d", status.MatchedWorkloads, status.ViolatingWorkloads)
+		}
+
+		expectCondition(t, status, v1.ConditionTrue, now)
+	})
+
+	t.Run("with violating workloads", func(t *testing.T) {
+		results := []audit.Result{
+			result(1, workload("b", "web"), nil),
+			result(1, workload("b", "api"), violation()),
+			result(1, workload("a", "web"), violation()),
+		}
+
+		status := audit.PolicyStatus(1, v1alpha1.HighAvailabilityPolicyStatus{}, results, now)
+
+		if status.MatchedWorkloads != 3 {
+			t.Errorf("Expected 3 matched workloads, got %d", status.MatchedWorkloads)
+		}
+
+		if status.ViolatingWorkloads != 2 {
+			t.Errorf("Expected 2 violating workloads, got %d", status.ViolatingWorkloads)
+		}
+
+		if len(status.Violations) != 2 || status.Violations[0].Namespace != "a" || status.Violations[1].Name != "api" {
+			t.Errorf("Expected the violations to be sorted, got %v", status.Violations)
+		}
+
+		expectCondition(t, status, v1.ConditionFalse, now)
+	})
+
+	t.Run("with workloads of different kinds", func(t *testing.T) {
+		sts := workload("default", "web")
+		sts.Kind = "StatefulSet"
+
+		ds := workload("default", "web")
+		ds.Kind = "DaemonSet"
+
+		results := []audit.Result{
+			result(1, workload("default", "web"), violation()),
+			result(1, sts, violation()),
+			result(1, ds, nil),
+		}
+
+		status := audit.PolicyStatus(1, v1alpha1.HighAvailabilityPolicyStatus{}, results, now)
+
+		if status.MatchedWorkloads != 3 {
+			t.Errorf("Expected 3 matched workloads, got %d", status.MatchedWorkloads)
+		}
+
+		if status.ViolatingWorkloads != 2 {
+			t.Errorf("Expected 2 violating workloads, got %d", status.ViolatingWorkloads)
+		}
+
+		if len(status.Violations) != 2 || status.Violations[0].Kind != "Deployment" || status.Violations[1].Kind != "StatefulSet" {
+			t.Errorf("Expected the violations to be sorted by kind, got %v", status.Violations)
+		}
+	})
+
+	t.Run("with more violations than can be listed", func(t *testing.T) {
+		results := []audit.Result{}
+		for i := 0; i < v1alpha1.MaxStatusViolations+5; i++ {
+			results = append(results, result(1, workload("default", fmt.Sprintf("web-%02d", i)), violation()))
+		}
+
+		status := audit.PolicyStatus(1, v1alpha1.HighAvailabilityPolicyStatus{}, results, now)
+
+		if status.ViolatingWorkloads != int32(len(results)) {
+			t.Errorf("Expected %d violating workloads, got %d", len(results), status.ViolatingWorkloads)
+		}
+
+		if len(status.Violations) != v1alpha1.MaxStatusViolations {
+			t.Errorf("Expected %d violations to be listed, got %d", v1alpha1.MaxStatusViolations, len(status.Violations))
+		}
+	})
+
+	t.Run("with an unchanged condition", func(t *testing.T) {
+		current := audit.PolicyStatus(1, v1alpha1.HighAvailabilityPolicyStatus{}, nil, then)
+		status := audit.PolicyStatus(1, current, nil, now)
+
+		expectCondition(t, status, v1.ConditionTrue, then)
+	})
+
+	t.Run("with a changed condition", func(t *testing.T) {
+		current := audit.PolicyStatus(1, v1alpha1.HighAvailabilityPolicyStatus{}, nil, then)
+		results := []audit.Result{result(1, workload("default", "web"), violation())}
+		status := audit.PolicyStatus(1, current, results, now)
+
+		expectCondition(t, status, v1.ConditionFalse, now)
+	})
+
+	t.Run("with results of an older generation", func(t *testing.T) {
+		current := audit.PolicyStatus(1, v1alpha1.HighAvailabilityPolicyStatus{}, nil, then)
+		results := []audit.Result{
+			result(2, workload("default", "web"), nil),
+			result(1, workload("default", "api"), violation()),
+		}
+
+		status := audit.PolicyStatus(2, current, results, now)
+		if status.ObservedGeneration != 1 {
+			t.Errorf("Expected observed generation 1 until every workload is validated, got %d", status.ObservedGeneration)
+		}
+
+		results[1] = result(2, workload("default", "api"), nil)
+		status = audit.PolicyStatus(2, current, results, now)
+		if status.ObservedGeneration != 2 {
+			t.Errorf("Expected observed generation 2, got %d", status.ObservedGeneration)
+		}
+	})
+}
+
+func expectCondition(t *testing.T, status v1alpha1.HighAvailabilityPolicyStatus, expected v1.ConditionStatus, transition metav1.Time) {
+	t.Helper()
+
+	if len(status.Conditions) != 1 {
+		t.Fatalf("Expected a single condition, got %d", len(status.Conditions))
+	}
+
+	cond := status.Conditions[0]
+	if cond.Type != v1alpha1.HighAvailabilityPolicyCompliant || cond.Status != expected {
+		t.Errorf("Expected condition %s to be %s, got %s %s", v1alpha1.HighAvailabilityPolicyCompliant, expected, cond.Type, cond.Status)
+	}
+
+	if !cond.LastTransitionTime.Equal(&transition) {
+		t.Errorf("Expected transition time %s, got %s", transition, cond.LastTransitionTime)
+	}
+}
+
+func workload(namespace, name string) v1.ObjectReference {
+	return v1.ObjectReference{Kind: "Deployment", APIVersion: "apps/v1", Namespace: namespace, Name: name}
+}
+
+func result(generation int64, ref v1.ObjectReference, el field.ErrorList) audit.Result {
+	return audit.Result{Generation: generation, Result: report.Result{Workload: ref, Errors: el}}
+}
+
+func violation() field.ErrorList {
+	return field.ErrorList{field.Invalid(field.NewPath("spec", "replicas"), 1, "should be at least 2")}
+}
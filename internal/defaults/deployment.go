@@ -0,0 +1,224 @@
+// Package defaults calculates the changes a workload needs to conform with the
+// HighAvailabilityPolicy that selects it. The changes are expressed as a
+// JSONPatch so they can be returned by a mutating admission hook.
+package defaults
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// PatchOperation is a single JSONPatch (RFC 6902) operation.
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Deployment calculates the patch to apply to a Deployment so the values it's
+// missing conform with the HighAvailabilityPolicy. Values which are set are
+// only changed when they fall outside of the policy boundaries, with the
+// exception of resources: only missing resource requests are filled in.
+func Deployment(dpl appsv1.Deployment, hap v1alpha1.HighAvailabilityPolicy) []PatchOperation {
+	ops := []PatchOperation{}
+
+	replicas := int32(1)
+	if dpl.Spec.Replicas != nil {
+		replicas = *dpl.Spec.Replicas
+	}
+
+	if hap.Spec.Replicas != nil {
+		desired := replicas
+		if dpl.Spec.Replicas == nil || desired < hap.Spec.Replicas.Minimum {
+			desired = hap.Spec.Replicas.Minimum
+		}
+
+		if maximum := hap.Spec.Replicas.Maximum; maximum != nil && desired > *maximum {
+			desired = *maximum
+		}
+
+		if dpl.Spec.Replicas == nil || desired != replicas {
+			replicas = desired
+			ops = append(ops, add("/spec/replicas", replicas))
+		}
+	}
+
+	return append(ops, deploymentSpec(dpl, replicas, hap)...)
+}
+
+// AutoscaledDeployment calculates the patch to apply to a Deployment of which
+// the replica count is managed by a HorizontalPodAutoscaler. The replica count
+// is left to the autoscaler, everything else is patched like Deployment does.
+func AutoscaledDeployment(dpl appsv1.Deployment, hap v1alpha1.HighAvailabilityPolicy) []PatchOperation {
+	replicas := int32(1)
+	if dpl.Spec.Replicas != nil {
+		replicas = *dpl.Spec.Replicas
+	}
+
+	return deploymentSpec(dpl, replicas, hap)
+}
+
+// deploymentSpec calculates the patch for everything but the replica count of
+// a Deployment.
+func deploymentSpec(dpl appsv1.Deployment, replicas int32, hap v1alpha1.HighAvailabilityPolicy) []PatchOperation {
+	ops := deploymentStrategy(dpl.Spec.Strategy, int(replicas), hap)
+	return append(ops, resourceRequests("/spec/template/spec/containers", dpl.Spec.Template.Spec.Containers, hap)...)
+}
+
+func deploymentStrategy(strategy appsv1.DeploymentStrategy, replicas int, hap v1alpha1.HighAvailabilityPolicy) []PatchOperation {
+	ops := []PatchOperation{}
+
+	hapStrategy := hap.Spec.Strategy
+	if hapStrategy == nil {
+		return ops
+	}
+
+	// a policy without a type keeps the type of the Deployment, which the API
+	// server defaults to RollingUpdate.
+	strategyType := hapStrategy.Type
+	if strategyType == "" {
+		strategyType = strategy.Type
+		if strategyType == "" {
+			strategyType = appsv1.RollingUpdateDeploymentStrategyType
+		}
+	} else if strategy.Type != strategyType {
+		ops = append(ops, add("/spec/strategy/type", strategyType))
+	}
+
+	if strategyType != appsv1.RollingUpdateDeploymentStrategyType {
+		// a rolling update configuration is only allowed together with the
+		// RollingUpdate type.
+		if strategy.RollingUpdate != nil {
+			ops = append(ops, PatchOperation{Op: "remove", Path: "/spec/strategy/rollingUpdate"})
+		}
+
+		return ops
+	}
+
+	hapUpdate := hapStrategy.RollingUpdate
+	if hapUpdate == nil {
+		return ops
+	}
+
+	current := appsv1.RollingUpdateDeployment{}
+	if strategy.RollingUpdate != nil {
+		current = *strategy.RollingUpdate
+	}
+
+	desired := current
+	if current.MaxSurge == nil || exceeds(hapUpdate.MinSurge, current.MaxSurge, replicas) {
+		desired.MaxSurge = hapUpdate.MinSurge
+	}
+
+	if desired.MaxSurge == nil || exceeds(desired.MaxSurge, hapUpdate.MaxSurge, replicas) {
+		desired.MaxSurge = hapUpdate.MaxSurge
+	}
+
+	if current.MaxUnavailable == nil || exceeds(current.MaxUnavailable, hapUpdate.MaxUnavailable, replicas) {
+		desired.MaxUnavailable = hapUpdate.MaxUnavailable
+	}
+
+	if strategy.RollingUpdate == nil {
+		return append(ops, add("/spec/strategy/rollingUpdate", desired))
+	}
+
+	if desired.MaxSurge != current.MaxSurge {
+		ops = append(ops, add("/spec/strategy/rollingUpdate/maxSurge", desired.MaxSurge))
+	}
+
+	if desired.MaxUnavailable != current.MaxUnavailable {
+		ops = append(ops, add("/spec/strategy/rollingUpdate/maxUnavailable", desired.MaxUnavailable))
+	}
+
+	return ops
+}
+
+// exceeds checks if value a is larger than value b when they're scaled against
+// the given number of replicas. When one of the values is missing or can't be
+// scaled, it doesn't exceed the other.
+func exceeds(a, b *intstr.IntOrString, replicas int) bool {
+	if a == nil || b == nil {
+		return false
+	}
+
+	aVal, err := intstr.GetValueFromIntOrPercent(a, replicas, true)
+	if err != nil {
+		return false
+	}
+
+	bVal, err := intstr.GetValueFromIntOrPercent(b, replicas, true)
+	if err != nil {
+		return false
+	}
+
+	return aVal > bVal
+}
+
+// resourceRequests fills in the missing resource requests for which the
+// policy has a minimum configured, using that minimum as the value.
+func resourceRequests(path string, containers []v1.Container, hap v1alpha1.HighAvailabilityPolicy) []PatchOperation {
+	ops := []PatchOperation{}
+
+	if hap.Spec.Resources == nil || len(hap.Spec.Resources.MinRequests) == 0 {
+		return ops
+	}
+
+	minRequests := hap.Spec.Resources.MinRequests
+	names := make([]v1.ResourceName, 0, len(minRequests))
+	for name := range minRequests {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+
+	for i, container := range containers {
+		rPath := path + "/" + strconv.Itoa(i) + "/resources/requests"
+
+		missing := v1.ResourceList{}
+		for _, name := range names {
+			if _, ok := container.Resources.Requests[name]; !ok {
+				missing[name] = minRequests[name]
+			}
+		}
+
+		if len(missing) == 0 {
+			continue
+		}
+
+		// without any requests, there's no map to add the values to yet.
+		if container.Resources.Requests == nil {
+			ops = append(ops, add(rPath, missing))
+			continue
+		}
+
+		for _, name := range names {
+			if val, ok := missing[name]; ok {
+				ops = append(ops, add(rPath+"/"+escapePointer(string(name)), val))
+			}
+		}
+	}
+
+	return ops
+}
+
+// add creates an add operation. When the path already exists in an object,
+// the add operation replaces its value.
+func add(path string, value interface{}) PatchOperation {
+	return PatchOperation{Op: "add", Path: path, Value: value}
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// escapePointer escapes a key so it can be used as a JSON Pointer reference
+// token, resource names like `nvidia.com/gpu` contain a slash.
+func escapePointer(key string) string {
+	return pointerEscaper.Replace(key)
+}
@@ -0,0 +1,259 @@
+package defaults_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+	"github.com/jelmersnoeck/barbossa/internal/defaults"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestDeployment(t *testing.T) {
+	hap := v1alpha1.HighAvailabilityPolicy{
+		Spec: v1alpha1.HighAvailabilityPolicySpec{
+			Replicas: &v1alpha1.HighAvailabilityPolicyReplicas{
+				Minimum: 2,
+			},
+			Strategy: &v1alpha1.HighAvailabilityPolicyStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &v1alpha1.HighAvailabilityPolicyRollingUpdate{
+					MinSurge:       fromIntStr("25%"),
+					MaxSurge:       fromIntStr("100%"),
+					MaxUnavailable: fromInt(0),
+				},
+			},
+			Resources: &v1alpha1.HighAvailabilityPolicyResourceRequirements{
+				MinRequests: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("100m"),
+					v1.ResourceMemory: resource.MustParse("256M"),
+				},
+			},
+		},
+	}
+
+	tcs := map[string]struct {
+		dpl   appsv1.DeploymentSpec
+		patch string
+	}{
+		"with a conforming spec": {
+			dpl: appsv1.DeploymentSpec{
+				Replicas: ptrInt32(3),
+				Strategy: appsv1.DeploymentStrategy{
+					Type: appsv1.RollingUpdateDeploymentStrategyType,
+					RollingUpdate: &appsv1.RollingUpdateDeployment{
+						MaxSurge:       fromIntStr("50%"),
+						MaxUnavailable: fromInt(0),
+					},
+				},
+			},
+			patch: `[]`,
+		},
+		"with an empty spec": {
+			dpl:   appsv1.DeploymentSpec{},
+			patch: `[{"op":"add","path":"/spec/replicas","value":2},{"op":"add","path":"/spec/strategy/type","value":"RollingUpdate"},{"op":"add","path":"/spec/strategy/rollingUpdate","value":{"maxUnavailable":0,"maxSurge":"25%"}}]`,
+		},
+		"with a Recreate strategy": {
+			dpl: appsv1.DeploymentSpec{
+				Replicas: ptrInt32(1),
+				Strategy: appsv1.DeploymentStrategy{
+					Type: appsv1.RecreateDeploymentStrategyType,
+				},
+			},
+			patch: `[{"op":"add","path":"/spec/replicas","value":2},{"op":"add","path":"/spec/strategy/type","value":"RollingUpdate"},{"op":"add","path":"/spec/strategy/rollingUpdate","value":{"maxUnavailable":0,"maxSurge":"25%"}}]`,
+		},
+		"with the default rolling update values": {
+			dpl: appsv1.DeploymentSpec{
+				Replicas: ptrInt32(10),
+				Strategy: appsv1.DeploymentStrategy{
+					Type: appsv1.RollingUpdateDeploymentStrategyType,
+					RollingUpdate: &appsv1.RollingUpdateDeployment{
+						MaxSurge:       fromIntStr("10%"),
+						MaxUnavailable: fromIntStr("25%"),
+					},
+				},
+			},
+			patch: `[{"op":"add","path":"/spec/strategy/rollingUpdate/maxSurge","value":"25%"},{"op":"add","path":"/spec/strategy/rollingUpdate/maxUnavailable","value":0}]`,
+		},
+		"with missing resource requests": {
+			dpl: appsv1.DeploymentSpec{
+				Replicas: ptrInt32(3),
+				Strategy: appsv1.DeploymentStrategy{
+					Type: appsv1.RollingUpdateDeploymentStrategyType,
+					RollingUpdate: &appsv1.RollingUpdateDeployment{
+						MaxSurge:       fromIntStr("50%"),
+						MaxUnavailable: fromInt(0),
+					},
+				},
+				Template: v1.PodTemplateSpec{
+					Spec: v1.PodSpec{
+						Containers: []v1.Container{
+							{Name: "app"},
+							{
+								Name: "sidecar",
+								Resources: v1.ResourceRequirements{
+									Requests: v1.ResourceList{
+										v1.ResourceCPU: resource.MustParse("1"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			patch: `[{"op":"add","path":"/spec/template/spec/containers/0/resources/requests","value":{"cpu":"100m","memory":"256M"}},{"op":"add","path":"/spec/template/spec/containers/1/resources/requests/memory","value":"256M"}]`,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			ops := defaults.Deployment(appsv1.Deployment{Spec: tc.dpl}, hap)
+
+			patch, err := json.Marshal(ops)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(patch) != tc.patch {
+				t.Errorf("Expected\n%s\nbut got \n%s", tc.patch, patch)
+			}
+		})
+	}
+
+	t.Run("with a replica count above the maximum", func(t *testing.T) {
+		hap := v1alpha1.HighAvailabilityPolicy{
+			Spec: v1alpha1.HighAvailabilityPolicySpec{
+				Replicas: &v1alpha1.HighAvailabilityPolicyReplicas{
+					Minimum: 2,
+					Maximum: ptrInt32(5),
+				},
+			},
+		}
+
+		dpl := appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptrInt32(10),
+			},
+		}
+
+		patch, err := json.Marshal(defaults.Deployment(dpl, hap))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := `[{"op":"add","path":"/spec/replicas","value":5}]`
+		if string(patch) != expected {
+			t.Errorf("Expected\n%s\nbut got \n%s", expected, patch)
+		}
+	})
+
+	t.Run("with an autoscaled Deployment", func(t *testing.T) {
+		hap := v1alpha1.HighAvailabilityPolicy{
+			Spec: v1alpha1.HighAvailabilityPolicySpec{
+				Replicas: &v1alpha1.HighAvailabilityPolicyReplicas{
+					Minimum: 2,
+					Maximum: ptrInt32(5),
+				},
+				Strategy: &v1alpha1.HighAvailabilityPolicyStrategy{
+					Type: appsv1.RecreateDeploymentStrategyType,
+				},
+			},
+		}
+
+		// the autoscaler manages the replica count, only the other values
+		// are patched.
+		for _, replicas := range []*int32{nil, ptrInt32(1), ptrInt32(10)} {
+			dpl := appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{
+					Replicas: replicas,
+				},
+			}
+
+			patch, err := json.Marshal(defaults.AutoscaledDeployment(dpl, hap))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := `[{"op":"add","path":"/spec/strategy/type","value":"Recreate"}]`
+			if string(patch) != expected {
+				t.Errorf("Expected\n%s\nbut got \n%s", expected, patch)
+			}
+		}
+	})
+
+	t.Run("with a Recreate policy", func(t *testing.T) {
+		hap := v1alpha1.HighAvailabilityPolicy{
+			Spec: v1alpha1.HighAvailabilityPolicySpec{
+				Strategy: &v1alpha1.HighAvailabilityPolicyStrategy{
+					Type: appsv1.RecreateDeploymentStrategyType,
+				},
+			},
+		}
+
+		dpl := appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Strategy: appsv1.DeploymentStrategy{
+					Type:          appsv1.RollingUpdateDeploymentStrategyType,
+					RollingUpdate: &appsv1.RollingUpdateDeployment{},
+				},
+			},
+		}
+
+		patch, err := json.Marshal(defaults.Deployment(dpl, hap))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := `[{"op":"add","path":"/spec/strategy/type","value":"Recreate"},{"op":"remove","path":"/spec/strategy/rollingUpdate"}]`
+		if string(patch) != expected {
+			t.Errorf("Expected\n%s\nbut got \n%s", expected, patch)
+		}
+	})
+
+	t.Run("with a policy without a strategy type", func(t *testing.T) {
+		hap := v1alpha1.HighAvailabilityPolicy{
+			Spec: v1alpha1.HighAvailabilityPolicySpec{
+				Strategy: &v1alpha1.HighAvailabilityPolicyStrategy{
+					MinReadySeconds: &v1alpha1.Bounds{
+						Minimum: ptrInt32(5),
+					},
+				},
+			},
+		}
+
+		for _, strategy := range []appsv1.DeploymentStrategyType{"", appsv1.RecreateDeploymentStrategyType} {
+			dpl := appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{
+					Strategy: appsv1.DeploymentStrategy{Type: strategy},
+				},
+			}
+
+			patch, err := json.Marshal(defaults.Deployment(dpl, hap))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(patch) != `[]` {
+				t.Errorf("Expected no patch for a %q strategy, got \n%s", strategy, patch)
+			}
+		}
+	})
+}
+
+func ptrInt32(i int32) *int32 {
+	return &i
+}
+
+func fromInt(v int) *intstr.IntOrString {
+	iv := intstr.FromInt(v)
+	return &iv
+}
+
+func fromIntStr(v string) *intstr.IntOrString {
+	sv := intstr.FromString(v)
+	return &sv
+}
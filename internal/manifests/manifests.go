@@ -0,0 +1,345 @@
+// Package manifests validates workload manifests against the policies defined
+// in manifests, without a connection to a cluster. This allows us to catch
+// policy violations before the manifests are applied, for example in CI.
+//
+// The policies are selected the same way the admission hook selects them. The
+// labels of a namespace are only known when its manifest is part of the Set,
+// other namespaces are considered to have no labels. The workloads get the
+// defaults the API server sets for the API version of their manifest before
+// they're validated, like the workloads the admission hook receives.
+package manifests
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1/validation"
+	"github.com/jelmersnoeck/barbossa/internal/policy"
+	"github.com/jelmersnoeck/barbossa/internal/report"
+	"github.com/jelmersnoeck/barbossa/internal/workloads"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Set is a collection of policies and workloads read from manifests.
+type Set struct {
+	defaultNamespace string
+
+	policies        []*v1alpha1.HighAvailabilityPolicy
+	clusterPolicies []*v1alpha1.ClusterHighAvailabilityPolicy
+	exceptions      []*v1alpha1.HighAvailabilityPolicyException
+	namespaces      map[string]labels.Set
+	budgets         []policyv1beta1.PodDisruptionBudget
+	deployments     []appsv1.Deployment
+	autoscalers     []autoscalingv1.HorizontalPodAutoscaler
+	workloads       []workload
+}
+
+// workload is a decoded workload which can be validated against a policy.
+type workload struct {
+	source   string
+	ref      v1.ObjectReference
+	meta     metav1.ObjectMeta
+	validate func(v1alpha1.HighAvailabilityPolicy) field.ErrorList
+}
+
+// NewSet creates an empty Set. Objects in the manifests without a namespace
+// are placed in the given default namespace.
+func NewSet(defaultNamespace string) *Set {
+	return &Set{
+		defaultNamespace: defaultNamespace,
+		namespaces:       map[string]labels.Set{},
+	}
+}
+
+// ReadPath reads the manifests from a file or, when the path is a directory,
+// from all the YAML and JSON files within that directory and its
+// subdirectories.
+func (s *Set) ReadPath(path string) error {
+	return filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		// files which are explicitly given are always read, within
+		// directories we only read the manifest files.
+		if file != path && !isManifest(file) {
+			return nil
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		return s.Read(file, f)
+	})
+}
+
+// Read reads all the documents from a YAML or JSON stream. Documents with a
+// kind we don't validate or use for validation are ignored.
+func (s *Set) Read(source string, r io.Reader) error {
+	reader := yaml.NewYAMLReader(bufio.NewReader(r))
+
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("%s: %s", source, err)
+		}
+
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		raw, err := yaml.ToJSON(doc)
+		if err != nil {
+			return fmt.Errorf("%s: %s", source, err)
+		}
+
+		if err := s.add(source, raw); err != nil {
+			return fmt.Errorf("%s: %s", source, err)
+		}
+	}
+}
+
+func (s *Set) add(source string, raw []byte) error {
+	tm := metav1.TypeMeta{}
+	if err := json.Unmarshal(raw, &tm); err != nil {
+		return err
+	}
+
+	// empty documents, for example the ones which only contain comments,
+	// are decoded as null.
+	if tm.Kind == "" {
+		return nil
+	}
+
+	gvk := schema.FromAPIVersionAndKind(tm.APIVersion, tm.Kind)
+
+	switch {
+	case gvk.GroupVersion() == v1alpha1.SchemeGroupVersion && gvk.Kind == "HighAvailabilityPolicy":
+		hap := &v1alpha1.HighAvailabilityPolicy{}
+		if err := json.Unmarshal(raw, hap); err != nil {
+			return err
+		}
+
+		hap.Namespace = s.namespace(hap.Namespace)
+		s.policies = append(s.policies, hap)
+	case gvk.GroupVersion() == v1alpha1.SchemeGroupVersion && gvk.Kind == policy.ClusterPolicyKind:
+		chap := &v1alpha1.ClusterHighAvailabilityPolicy{}
+		if err := json.Unmarshal(raw, chap); err != nil {
+			return err
+		}
+
+		s.clusterPolicies = append(s.clusterPolicies, chap)
+	case gvk.GroupVersion() == v1alpha1.SchemeGroupVersion && gvk.Kind == policy.ExceptionKind:
+		exception := &v1alpha1.HighAvailabilityPolicyException{}
+		if err := json.Unmarshal(raw, exception); err != nil {
+			return err
+		}
+
+		exception.Namespace = s.namespace(exception.Namespace)
+		s.exceptions = append(s.exceptions, exception)
+	case gvk == v1.SchemeGroupVersion.WithKind("Namespace"):
+		ns := &v1.Namespace{}
+		if err := json.Unmarshal(raw, ns); err != nil {
+			return err
+		}
+
+		s.namespaces[ns.Name] = labels.Set(ns.Labels)
+	case gvk == policyv1beta1.SchemeGroupVersion.WithKind("PodDisruptionBudget"):
+		pdb := policyv1beta1.PodDisruptionBudget{}
+		if err := json.Unmarshal(raw, &pdb); err != nil {
+			return err
+		}
+
+		pdb.Namespace = s.namespace(pdb.Namespace)
+		s.budgets = append(s.budgets, pdb)
+	case workloads.IsHorizontalPodAutoscaler(gvk):
+		hpa, err := workloads.DecodeHorizontalPodAutoscaler(gvk, raw)
+		if err != nil {
+			return err
+		}
+
+		hpa.Namespace = s.namespace(hpa.Namespace)
+		s.autoscalers = append(s.autoscalers, *hpa)
+	case workloads.IsDeployment(gvk):
+		dpl, err := workloads.DecodeDeployment(gvk, raw)
+		if err != nil {
+			return err
+		}
+
+		dpl.Namespace = s.namespace(dpl.Namespace)
+		workloads.DefaultDeployment(gvk.GroupVersion(), dpl)
+		s.deployments = append(s.deployments, *dpl)
+		s.workloads = append(s.workloads, workload{
+			source: source,
+			ref:    reference(gvk, dpl.ObjectMeta),
+			meta:   dpl.ObjectMeta,
+			validate: func(hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+				return s.validateDeployment(*dpl, hap)
+			},
+		})
+	case workloads.IsStatefulSet(gvk):
+		sts, err := workloads.DecodeStatefulSet(gvk, raw)
+		if err != nil {
+			return err
+		}
+
+		sts.Namespace = s.namespace(sts.Namespace)
+		workloads.DefaultStatefulSet(gvk.GroupVersion(), sts)
+		s.workloads = append(s.workloads, workload{
+			source: source,
+			ref:    reference(gvk, sts.ObjectMeta),
+			meta:   sts.ObjectMeta,
+			validate: func(hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+				return validation.ValidateStatefulSet(*sts, hap)
+			},
+		})
+	case workloads.IsDaemonSet(gvk):
+		ds, err := workloads.DecodeDaemonSet(gvk, raw)
+		if err != nil {
+			return err
+		}
+
+		ds.Namespace = s.namespace(ds.Namespace)
+		workloads.DefaultDaemonSet(gvk.GroupVersion(), ds)
+		s.workloads = append(s.workloads, workload{
+			source: source,
+			ref:    reference(gvk, ds.ObjectMeta),
+			meta:   ds.ObjectMeta,
+			validate: func(hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+				return validation.ValidateDaemonSet(*ds, hap)
+			},
+		})
+	}
+
+	return nil
+}
+
+// Validate validates all the workloads in the Set against the policy which
+// selects them, without the sections they're exempted from by the exceptions
+// in the Set. Workloads which aren't selected by any policy are left out of
+// the results.
+func (s *Set) Validate() ([]report.Result, error) {
+	results := []report.Result{}
+
+	for _, w := range s.workloads {
+		haps := []*v1alpha1.HighAvailabilityPolicy{}
+		for _, hap := range s.policies {
+			if hap.Namespace == w.meta.Namespace {
+				haps = append(haps, hap)
+			}
+		}
+
+		candidates, err := policy.Candidates(w.meta, s.namespaces[w.meta.Namespace], haps, s.clusterPolicies)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(candidates) == 0 {
+			continue
+		}
+
+		candidates, _, err = policy.Except(w.meta, candidates, s.exceptions, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		hap, _, el := policy.Exempt(w.meta, policy.Merge(candidates), time.Now())
+		results = append(results, report.Result{
+			Source:   w.source,
+			Workload: w.ref,
+			Policy:   policy.Describe(hap),
+			Errors:   append(el, w.validate(*hap)...),
+		})
+	}
+
+	return results, nil
+}
+
+func (s *Set) validateDeployment(dpl appsv1.Deployment, hap v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+	hpas := []autoscalingv1.HorizontalPodAutoscaler{}
+	for _, hpa := range s.autoscalers {
+		if hpa.Namespace == dpl.Namespace {
+			hpas = append(hpas, hpa)
+		}
+	}
+
+	var el field.ErrorList
+	if hpa := workloads.Autoscaler(workloads.DeploymentKind, dpl.Name, hpas); hpa != nil {
+		el = validation.ValidateAutoscaledDeployment(dpl, *hpa, hap)
+	} else {
+		el = validation.ValidateDeployment(dpl, hap)
+	}
+
+	if hap.Spec.Disruptions != nil {
+		pdbs := []policyv1beta1.PodDisruptionBudget{}
+		for _, pdb := range s.budgets {
+			if pdb.Namespace == dpl.Namespace {
+				pdbs = append(pdbs, pdb)
+			}
+		}
+
+		dpls := []appsv1.Deployment{}
+		for _, d := range s.deployments {
+			if d.Namespace == dpl.Namespace {
+				dpls = append(dpls, d)
+			}
+		}
+
+		el = append(el, validation.ValidateDisruptions(dpl, pdbs, dpls, hap)...)
+	}
+
+	return el
+}
+
+func (s *Set) namespace(ns string) string {
+	if ns == "" {
+		return s.defaultNamespace
+	}
+
+	return ns
+}
+
+func reference(gvk schema.GroupVersionKind, meta metav1.ObjectMeta) v1.ObjectReference {
+	apiVersion, kind := gvk.ToAPIVersionAndKind()
+	return v1.ObjectReference{
+		Kind:       kind,
+		APIVersion: apiVersion,
+		Namespace:  meta.Namespace,
+		Name:       meta.Name,
+	}
+}
+
+func isManifest(file string) bool {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,338 @@
+package manifests_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jelmersnoeck/barbossa/internal/manifests"
+)
+
+const policies = `
+apiVersion: barbossa.sphc.io/v1alpha1
+kind: HighAvailabilityPolicy
+metadata:
+  name: default
+spec:
+  weight: 1
+  selector:
+    matchLabels: {}
+  replicas:
+    minimum: 2
+---
+# a cluster policy which only applies to production namespaces
+apiVersion: barbossa.sphc.io/v1alpha1
+kind: ClusterHighAvailabilityPolicy
+metadata:
+  name: production
+spec:
+  weight: 1
+  namespaceSelector:
+    matchLabels:
+      env: production
+  selector:
+    matchLabels: {}
+  replicas:
+    minimum: 3
+---
+apiVersion: barbossa.sphc.io/v1alpha1
+kind: HighAvailabilityPolicyException
+metadata:
+  name: batch
+spec:
+  policy:
+    name: default
+  selector:
+    matchLabels:
+      app: batch
+  sections:
+  - replicas
+  reason: batch jobs are retried when they're disrupted
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: production
+  labels:
+    env: production
+`
+
+const workloads = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 1
+---
+apiVersion: extensions/v1beta1
+kind: Deployment
+metadata:
+  name: api
+spec:
+  replicas: 2
+---
+# the replica count of this deployment is managed by an autoscaler
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: worker
+spec:
+  replicas: 1
+---
+apiVersion: autoscaling/v1
+kind: HorizontalPodAutoscaler
+metadata:
+  name: worker
+spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: worker
+  minReplicas: 2
+  maxReplicas: 5
+---
+# this deployment is exempted from the replica count
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: scheduler
+  annotations:
+    barbossa.sphc.io/exempt: replicas
+    barbossa.sphc.io/exemption-justification: only a single instance may run
+spec:
+  replicas: 1
+---
+# this deployment is exempted from the replica count by an exception
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: batch
+  labels:
+    app: batch
+spec:
+  replicas: 1
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: production
+spec:
+  replicas: 2
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: staging
+spec:
+  replicas: 1
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+---
+`
+
+func TestSet(t *testing.T) {
+	set := manifests.NewSet("default")
+
+	if err := set.Read("policies.yaml", strings.NewReader(policies)); err != nil {
+		t.Fatalf("Expected no error reading the policies, got %s", err)
+	}
+
+	if err := set.Read("workloads.yaml", strings.NewReader(workloads)); err != nil {
+		t.Fatalf("Expected no error reading the workloads, got %s", err)
+	}
+
+	results, err := set.Validate()
+	if err != nil {
+		t.Fatalf("Expected no error validating the workloads, got %s", err)
+	}
+
+	expected := []struct {
+		workload string
+		policy   string
+		errors   int
+	}{
+		{"default/web", "HighAvailabilityPolicy default:default", 1},
+		{"default/api", "HighAvailabilityPolicy default:default", 0},
+		{"default/worker", "HighAvailabilityPolicy default:default", 0},
+		{"default/scheduler", "HighAvailabilityPolicy default:default", 0},
+		{"default/batch", "HighAvailabilityPolicy default:default", 0},
+		{"production/web", "ClusterHighAvailabilityPolicy production", 1},
+	}
+
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %d results, got %d: %v", len(expected), len(results), results)
+	}
+
+	for i, exp := range expected {
+		result := results[i]
+		workload := result.Workload.Namespace + "/" + result.Workload.Name
+
+		if workload != exp.workload || result.Policy != exp.policy {
+			t.Errorf("Expected %s to be validated against %s, got %s against %s", exp.workload, exp.policy, workload, result.Policy)
+		}
+
+		if len(result.Errors) != exp.errors {
+			t.Errorf("Expected %d errors for %s, got %v", exp.errors, exp.workload, result.Errors)
+		}
+
+		if result.Source != "workloads.yaml" {
+			t.Errorf("Expected the source to be workloads.yaml, got %s", result.Source)
+		}
+	}
+
+	if results[1].Workload.APIVersion != "extensions/v1beta1" {
+		t.Errorf("Expected the workload reference to keep its API version, got %s", results[1].Workload.APIVersion)
+	}
+}
+
+func TestSetDefaults(t *testing.T) {
+	set := manifests.NewSet("default")
+
+	err := set.Read("manifests.yaml", strings.NewReader(`
+apiVersion: barbossa.sphc.io/v1alpha1
+kind: HighAvailabilityPolicy
+metadata:
+  name: strategy
+spec:
+  selector:
+    matchLabels: {}
+  strategy:
+    type: RollingUpdate
+    rollingUpdate:
+      maxUnavailable: 50%
+    revisionHistoryLimit:
+      minimum: 5
+  statefulSet:
+    podManagementPolicy: OrderedReady
+---
+# the API server defaults the strategy of these workloads
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 4
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+`))
+	if err != nil {
+		t.Fatalf("Expected no error reading the manifests, got %s", err)
+	}
+
+	results, err := set.Validate()
+	if err != nil {
+		t.Fatalf("Expected no error validating the workloads, got %s", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d: %v", len(results), results)
+	}
+
+	for _, result := range results {
+		if len(result.Errors) != 0 {
+			t.Errorf("Expected the defaulted %s %s to comply, got %v", result.Workload.Kind, result.Workload.Name, result.Errors)
+		}
+	}
+}
+
+func TestSetDefaultsPerVersion(t *testing.T) {
+	set := manifests.NewSet("default")
+
+	err := set.Read("manifests.yaml", strings.NewReader(`
+apiVersion: barbossa.sphc.io/v1alpha1
+kind: HighAvailabilityPolicy
+metadata:
+  name: rollout
+spec:
+  selector:
+    matchLabels: {}
+  strategy:
+    revisionHistoryLimit:
+      maximum: 10
+  daemonSet:
+    updateStrategy:
+      type: RollingUpdate
+---
+# extensions/v1beta1 keeps the full revision history
+apiVersion: extensions/v1beta1
+kind: Deployment
+metadata:
+  name: legacy
+spec:
+  replicas: 2
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 2
+---
+# extensions/v1beta1 defaults to the OnDelete strategy
+apiVersion: extensions/v1beta1
+kind: DaemonSet
+metadata:
+  name: agent
+---
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: logs
+`))
+	if err != nil {
+		t.Fatalf("Expected no error reading the manifests, got %s", err)
+	}
+
+	results, err := set.Validate()
+	if err != nil {
+		t.Fatalf("Expected no error validating the workloads, got %s", err)
+	}
+
+	expected := map[string]string{
+		"legacy": "spec.revisionHistoryLimit",
+		"web":    "",
+		"agent":  "spec.updateStrategy.type",
+		"logs":   "",
+	}
+
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %d results, got %d: %v", len(expected), len(results), results)
+	}
+
+	for _, result := range results {
+		violated, ok := expected[result.Workload.Name]
+		if !ok {
+			t.Errorf("Expected no result for %s", result.Workload.Name)
+			continue
+		}
+
+		if violated == "" && len(result.Errors) != 0 {
+			t.Errorf("Expected %s %s to comply, got %v", result.Workload.Kind, result.Workload.Name, result.Errors)
+		}
+
+		if violated != "" && (len(result.Errors) != 1 || result.Errors[0].Field != violated) {
+			t.Errorf("Expected %s %s to violate %s, got %v", result.Workload.Kind, result.Workload.Name, violated, result.Errors)
+		}
+	}
+}
+
+func TestSetRead(t *testing.T) {
+	set := manifests.NewSet("default")
+
+	err := set.Read("invalid.yaml", strings.NewReader("apiVersion: apps/v1\nkind: Deployment\nspec: [\n"))
+	if err == nil {
+		t.Fatalf("Expected an error for an invalid manifest")
+	}
+
+	if !strings.HasPrefix(err.Error(), "invalid.yaml: ") {
+		t.Errorf("Expected the error to reference the source, got %s", err)
+	}
+}
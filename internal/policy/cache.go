@@ -0,0 +1,143 @@
+package policy
+
+import (
+	"log"
+	"time"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+	"github.com/jelmersnoeck/barbossa/pkg/client/generated/informers/externalversions"
+	listers "github.com/jelmersnoeck/barbossa/pkg/client/generated/listers/barbossa/v1alpha1"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Cache keeps a local copy of the HighAvailabilityPolicies,
+// ClusterHighAvailabilityPolicies and HighAvailabilityPolicyExceptions in the
+// cluster so we don't have to query the API server every time we select a
+// policy. The namespaces are cached as
+// well, their labels are needed to select cluster policies.
+type Cache struct {
+	kubeClient kubernetes.Interface
+	hapLister  listers.HighAvailabilityPolicyLister
+	chapLister listers.ClusterHighAvailabilityPolicyLister
+	excLister  listers.HighAvailabilityPolicyExceptionLister
+	nsLister   corelisters.NamespaceLister
+	synced     []cache.InformerSynced
+}
+
+// NewCache creates a Cache which uses the informers of the given factories.
+// The factories should be started and the cache synced before it's used.
+func NewCache(kubeClient kubernetes.Interface, crdInformers externalversions.SharedInformerFactory, kubeInformers informers.SharedInformerFactory) *Cache {
+	hapInformer := crdInformers.Barbossa().V1alpha1().HighAvailabilityPolicies()
+	chapInformer := crdInformers.Barbossa().V1alpha1().ClusterHighAvailabilityPolicies()
+	excInformer := crdInformers.Barbossa().V1alpha1().HighAvailabilityPolicyExceptions()
+	nsInformer := kubeInformers.Core().V1().Namespaces()
+
+	return &Cache{
+		kubeClient: kubeClient,
+		hapLister:  hapInformer.Lister(),
+		chapLister: chapInformer.Lister(),
+		excLister:  excInformer.Lister(),
+		nsLister:   nsInformer.Lister(),
+		synced: []cache.InformerSynced{
+			hapInformer.Informer().HasSynced,
+			chapInformer.Informer().HasSynced,
+			excInformer.Informer().HasSynced,
+			nsInformer.Informer().HasSynced,
+		},
+	}
+}
+
+// HasSynced reports if the informers used by the cache have synced.
+func (c *Cache) HasSynced() bool {
+	for _, synced := range c.synced {
+		if !synced() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Select selects the policy which takes precedence over all other policies
+// which select the given object: the policy with the highest weight, then the
+// one with the most specific selector, then a namespaced policy over a
+// ClusterHighAvailabilityPolicy and finally the first name in alphabetical
+// order. It's merged with the others when it opts in to it, see Merge. The
+// exceptions for the object are applied to it. When no policy selects the
+// object, nil is returned.
+func (c *Cache) Select(obj metav1.ObjectMeta) (*v1alpha1.HighAvailabilityPolicy, error) {
+	candidates, _, err := c.Candidates(obj)
+	if err != nil || len(candidates) == 0 {
+		return nil, err
+	}
+
+	return Merge(candidates), nil
+}
+
+// Candidates returns all the policies which select the given object ordered
+// by precedence, see Candidates: by weight, the highest first, by the
+// specificity of their selector, the most requirements first, by scope,
+// namespaced policies first, and by name, in alphabetical order. The sections
+// the object is exempted from by the HighAvailabilityPolicyExceptions in its
+// namespace are removed from the candidates which are used, the exceptions
+// which were applied are returned as well, see Except.
+func (c *Cache) Candidates(obj metav1.ObjectMeta) ([]*v1alpha1.HighAvailabilityPolicy, []*v1alpha1.HighAvailabilityPolicyException, error) {
+	haps, err := c.hapLister.HighAvailabilityPolicies(obj.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chaps, err := c.chapLister.List(labels.Everything())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var nsLabels labels.Set
+	if len(chaps) > 0 {
+		if nsLabels, err = c.namespaceLabels(obj.Namespace); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	candidates, err := Candidates(obj, nsLabels, haps, chaps)
+	if err != nil {
+		log.Printf("Could not select a policy for %s:%s: %s", obj.Namespace, obj.Name, err)
+		return nil, nil, err
+	}
+
+	exceptions, err := c.excLister.HighAvailabilityPolicyExceptions(obj.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	candidates, applied, err := Except(obj, candidates, exceptions, time.Now())
+	if err != nil {
+		log.Printf("Could not apply the exceptions for %s:%s: %s", obj.Namespace, obj.Name, err)
+		return nil, nil, err
+	}
+
+	return candidates, applied, nil
+}
+
+// namespaceLabels returns the labels of the given namespace. A namespace which
+// was just created might not be in the cache yet, in which case we fetch it
+// from the API server.
+func (c *Cache) namespaceLabels(name string) (labels.Set, error) {
+	ns, err := c.nsLister.Get(name)
+	if kerrors.IsNotFound(err) {
+		ns, err = c.kubeClient.CoreV1().Namespaces().Get(name, metav1.GetOptions{})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return labels.Set(ns.Labels), nil
+}
@@ -0,0 +1,96 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ExceptionKind is the Kind of a HighAvailabilityPolicyException.
+const ExceptionKind = "HighAvailabilityPolicyException"
+
+// Except removes the sections the given object is exempted from by the
+// exceptions from the candidates they reference. An exception applies when
+// it's in the namespace of the object, selects the object, hasn't expired and
+// references a candidate which is used for the object. The candidates should
+// be ordered by precedence, as returned by Candidates. Only the policy which
+// takes precedence is used, unless its composition is set to merge, in which
+// case the candidates are merged afterwards so an exempted section of the
+// selected policy can still be taken from another policy.
+//
+// The returned candidates are copies when sections are removed from them,
+// the exceptions which were applied are returned as well. An exception for a
+// candidate which isn't used isn't applied.
+func Except(
+	obj metav1.ObjectMeta,
+	candidates []*v1alpha1.HighAvailabilityPolicy,
+	exceptions []*v1alpha1.HighAvailabilityPolicyException,
+	now time.Time,
+) ([]*v1alpha1.HighAvailabilityPolicy, []*v1alpha1.HighAvailabilityPolicyException, error) {
+	excepted := make([]*v1alpha1.HighAvailabilityPolicy, len(candidates))
+	copy(excepted, candidates)
+
+	used := excepted
+	if len(used) > 0 && used[0].Spec.Composition != v1alpha1.CompositionMerge {
+		used = used[:1]
+	}
+
+	var applied []*v1alpha1.HighAvailabilityPolicyException
+	for _, exception := range exceptions {
+		if exception.Namespace != obj.Namespace || expired(exception, now) {
+			continue
+		}
+
+		matches, err := selects(exception.Spec.Selector, obj.Labels, false)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid selector for %s %s:%s: %s", ExceptionKind, exception.Namespace, exception.Name, err)
+		}
+
+		if !matches {
+			continue
+		}
+
+		for i, candidate := range used {
+			if references(exception, candidate) {
+				used[i] = Without(candidate, exception.Spec.Sections)
+				applied = append(applied, exception)
+			}
+		}
+	}
+
+	return excepted, applied, nil
+}
+
+// references checks if the exception applies to the given policy. A
+// namespaced policy is looked up in the namespace of the exception.
+func references(exception *v1alpha1.HighAvailabilityPolicyException, hap *v1alpha1.HighAvailabilityPolicy) bool {
+	ref := exception.Spec.Policy
+	if ref.Name != hap.Name {
+		return false
+	}
+
+	if ref.Kind == ClusterPolicyKind {
+		return hap.Kind == ClusterPolicyKind
+	}
+
+	return hap.Kind != ClusterPolicyKind && hap.Namespace == exception.Namespace
+}
+
+func expired(exception *v1alpha1.HighAvailabilityPolicyException, now time.Time) bool {
+	return exception.Spec.Expires != nil && now.After(exception.Spec.Expires.Time)
+}
+
+// DescribeException returns a human readable reference to the given
+// exception and the sections it exempts.
+func DescribeException(exception *v1alpha1.HighAvailabilityPolicyException) string {
+	sections := make([]string, len(exception.Spec.Sections))
+	for i, section := range exception.Spec.Sections {
+		sections[i] = string(section)
+	}
+
+	return fmt.Sprintf("%s %s:%s (%s)", ExceptionKind, exception.Namespace, exception.Name, strings.Join(sections, ", "))
+}
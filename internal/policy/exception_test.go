@@ -0,0 +1,141 @@
+package policy_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+	"github.com/jelmersnoeck/barbossa/internal/policy"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExcept(t *testing.T) {
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	obj := metav1.ObjectMeta{
+		Name:      "web",
+		Namespace: "default",
+		Labels:    map[string]string{"app": "web"},
+	}
+
+	team := namespacedPolicy("team", 10, map[string]string{})
+	team.Spec.Replicas = &v1alpha1.HighAvailabilityPolicyReplicas{Minimum: 2}
+	team.Spec.Strategy = &v1alpha1.HighAvailabilityPolicyStrategy{}
+
+	org := policy.FromCluster(clusterPolicy("team", 1, map[string]string{}, nil))
+	org.Spec.Replicas = &v1alpha1.HighAvailabilityPolicyReplicas{Minimum: 3}
+
+	merging := team.DeepCopy()
+	merging.Spec.Composition = v1alpha1.CompositionMerge
+
+	tcs := map[string]struct {
+		exception *v1alpha1.HighAvailabilityPolicyException
+		selected  *v1alpha1.HighAvailabilityPolicy
+		applied   bool
+		replicas  []bool
+	}{
+		"with an exception for the selected policy": {
+			exception: exception("default", v1alpha1.HighAvailabilityPolicyReference{Name: "team"}, map[string]string{"app": "web"}, nil),
+			applied:   true,
+			replicas:  []bool{false, true},
+		},
+		"with an exception for the cluster policy": {
+			exception: exception("default", v1alpha1.HighAvailabilityPolicyReference{Kind: policy.ClusterPolicyKind, Name: "team"}, map[string]string{}, nil),
+			replicas:  []bool{true, true},
+		},
+		"with an exception for the cluster policy which is merged": {
+			exception: exception("default", v1alpha1.HighAvailabilityPolicyReference{Kind: policy.ClusterPolicyKind, Name: "team"}, map[string]string{}, nil),
+			selected:  merging,
+			applied:   true,
+			replicas:  []bool{true, false},
+		},
+		"with an exception for another policy": {
+			exception: exception("default", v1alpha1.HighAvailabilityPolicyReference{Name: "other"}, map[string]string{}, nil),
+			replicas:  []bool{true, true},
+		},
+		"with an exception which doesn't select the object": {
+			exception: exception("default", v1alpha1.HighAvailabilityPolicyReference{Name: "team"}, map[string]string{"app": "api"}, nil),
+			replicas:  []bool{true, true},
+		},
+		"with an exception without a selector": {
+			exception: exception("default", v1alpha1.HighAvailabilityPolicyReference{Name: "team"}, nil, nil),
+			replicas:  []bool{true, true},
+		},
+		"with an exception in another namespace": {
+			exception: exception("production", v1alpha1.HighAvailabilityPolicyReference{Name: "team"}, map[string]string{}, nil),
+			replicas:  []bool{true, true},
+		},
+		"with an exception which hasn't expired": {
+			exception: exception("default", v1alpha1.HighAvailabilityPolicyReference{Name: "team"}, map[string]string{}, &metav1.Time{Time: now.Add(time.Hour)}),
+			applied:   true,
+			replicas:  []bool{false, true},
+		},
+		"with an expired exception": {
+			exception: exception("default", v1alpha1.HighAvailabilityPolicyReference{Name: "team"}, map[string]string{}, &metav1.Time{Time: now.Add(-time.Hour)}),
+			replicas:  []bool{true, true},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			selected := team
+			if tc.selected != nil {
+				selected = tc.selected
+			}
+
+			candidates := []*v1alpha1.HighAvailabilityPolicy{selected, org}
+			exceptions := []*v1alpha1.HighAvailabilityPolicyException{tc.exception}
+
+			excepted, applied, err := policy.Except(obj, candidates, exceptions, now)
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			if (len(applied) > 0) != tc.applied {
+				t.Errorf("Expected the exception to be applied to be %t, got %v", tc.applied, applied)
+			}
+
+			for i, hap := range excepted {
+				if (hap.Spec.Replicas != nil) != tc.replicas[i] {
+					t.Errorf("Expected the replicas of %s to be kept to be %t", policy.Describe(hap), tc.replicas[i])
+				}
+			}
+
+			if excepted[0].Spec.Strategy == nil {
+				t.Errorf("Expected the sections which aren't exempted to be kept")
+			}
+
+			if team.Spec.Replicas == nil || org.Spec.Replicas == nil {
+				t.Errorf("Expected the candidates not to be modified")
+			}
+		})
+	}
+}
+
+func TestDescribeException(t *testing.T) {
+	exc := exception("default", v1alpha1.HighAvailabilityPolicyReference{Name: "team"}, map[string]string{}, nil)
+	exc.Spec.Sections = append(exc.Spec.Sections, v1alpha1.SectionStrategy)
+
+	expected := "HighAvailabilityPolicyException default:singleton (replicas, strategy)"
+	if description := policy.DescribeException(exc); description != expected {
+		t.Errorf("Expected %s, got %s", expected, description)
+	}
+}
+
+func exception(namespace string, ref v1alpha1.HighAvailabilityPolicyReference, selector map[string]string, expires *metav1.Time) *v1alpha1.HighAvailabilityPolicyException {
+	exc := &v1alpha1.HighAvailabilityPolicyException{
+		ObjectMeta: metav1.ObjectMeta{Name: "singleton", Namespace: namespace},
+		Spec: v1alpha1.HighAvailabilityPolicyExceptionSpec{
+			Policy:   ref,
+			Sections: []v1alpha1.Section{v1alpha1.SectionReplicas},
+			Expires:  expires,
+			Reason:   "only a single instance may run",
+		},
+	}
+
+	if selector != nil {
+		exc.Spec.Selector = &metav1.LabelSelector{MatchLabels: selector}
+	}
+
+	return exc
+}
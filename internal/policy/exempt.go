@@ -0,0 +1,138 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var annotationsPath = field.NewPath("metadata", "annotations")
+
+// Exemption describes the sections of a policy a workload is exempted from
+// through its annotations, see v1alpha1.ExemptAnnotation.
+type Exemption struct {
+	Sections      []v1alpha1.Section
+	Justification string
+
+	// Expires is the time after which the exemption no longer applies, it's
+	// nil when the exemption doesn't expire.
+	Expires *time.Time
+}
+
+// Expired checks if the exemption no longer applies at the given time.
+func (e *Exemption) Expired(now time.Time) bool {
+	return e.Expires != nil && now.After(*e.Expires)
+}
+
+// String describes the exempted sections and the justification.
+func (e *Exemption) String() string {
+	sections := make([]string, len(e.Sections))
+	for i, section := range e.Sections {
+		sections[i] = string(section)
+	}
+
+	return fmt.Sprintf("%s (%s)", strings.Join(sections, ", "), e.Justification)
+}
+
+// ParseExemption parses the exemption annotations of the given object. It
+// returns nil when the object isn't exempted from any sections. An exemption
+// without a justification, with unknown sections or with an invalid expiry
+// is invalid and isn't returned, the problems are returned as errors instead.
+func ParseExemption(obj metav1.ObjectMeta) (*Exemption, field.ErrorList) {
+	value, ok := obj.Annotations[v1alpha1.ExemptAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	el := field.ErrorList{}
+	exemption := &Exemption{
+		Justification: strings.TrimSpace(obj.Annotations[v1alpha1.ExemptionJustificationAnnotation]),
+	}
+
+	for _, name := range strings.Split(value, ",") {
+		section := v1alpha1.Section(strings.TrimSpace(name))
+		if !knownSection(section) {
+			el = append(el, field.Invalid(annotationsPath.Key(v1alpha1.ExemptAnnotation), value, fmt.Sprintf("section '%s' is unknown", section)))
+			continue
+		}
+
+		exemption.Sections = append(exemption.Sections, section)
+	}
+
+	if exemption.Justification == "" {
+		el = append(el, field.Invalid(annotationsPath.Key(v1alpha1.ExemptionJustificationAnnotation), exemption.Justification, "is required"))
+	}
+
+	if expires, ok := obj.Annotations[v1alpha1.ExemptionExpiresAnnotation]; ok {
+		t, err := time.Parse(time.RFC3339, expires)
+		if err != nil {
+			el = append(el, field.Invalid(annotationsPath.Key(v1alpha1.ExemptionExpiresAnnotation), expires, "should be an RFC3339 timestamp"))
+		} else {
+			exemption.Expires = &t
+		}
+	}
+
+	if len(el) > 0 {
+		return nil, el
+	}
+
+	return exemption, nil
+}
+
+// Exempt removes the sections the given object is exempted from from the
+// policy. The returned exemption is nil when the object isn't exempted, and
+// when it's expired the policy is returned as is. The errors of an invalid
+// exemption are returned so the object can be denied for them.
+func Exempt(obj metav1.ObjectMeta, hap *v1alpha1.HighAvailabilityPolicy, now time.Time) (*v1alpha1.HighAvailabilityPolicy, *Exemption, field.ErrorList) {
+	exemption, el := ParseExemption(obj)
+	if exemption == nil || exemption.Expired(now) {
+		return hap, exemption, el
+	}
+
+	return Without(hap, exemption.Sections), exemption, nil
+}
+
+// Without returns a copy of the policy without the given sections.
+func Without(hap *v1alpha1.HighAvailabilityPolicy, sections []v1alpha1.Section) *v1alpha1.HighAvailabilityPolicy {
+	hap = hap.DeepCopy()
+
+	for _, section := range sections {
+		switch section {
+		case v1alpha1.SectionReplicas:
+			hap.Spec.Replicas = nil
+		case v1alpha1.SectionStrategy:
+			hap.Spec.Strategy = nil
+		case v1alpha1.SectionResources:
+			hap.Spec.Resources = nil
+		case v1alpha1.SectionDisruptions:
+			hap.Spec.Disruptions = nil
+		case v1alpha1.SectionStatefulSet:
+			hap.Spec.StatefulSet = nil
+		case v1alpha1.SectionDaemonSet:
+			hap.Spec.DaemonSet = nil
+		case v1alpha1.SectionScheduling:
+			hap.Spec.Scheduling = nil
+		case v1alpha1.SectionProbes:
+			hap.Spec.Probes = nil
+		case v1alpha1.SectionShutdown:
+			hap.Spec.Shutdown = nil
+		}
+	}
+
+	return hap
+}
+
+func knownSection(section v1alpha1.Section) bool {
+	for _, known := range v1alpha1.Sections {
+		if section == known {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,127 @@
+package policy_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+	"github.com/jelmersnoeck/barbossa/internal/policy"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExempt(t *testing.T) {
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	hap := namespacedPolicy("default", 10, map[string]string{})
+	hap.Spec.Replicas = &v1alpha1.HighAvailabilityPolicyReplicas{Minimum: 2}
+	hap.Spec.Strategy = &v1alpha1.HighAvailabilityPolicyStrategy{}
+	hap.Spec.Probes = &v1alpha1.HighAvailabilityPolicyProbes{}
+
+	tcs := map[string]struct {
+		annotations map[string]string
+		exempted    bool
+		errors      []string
+		replicas    bool
+		strategy    bool
+	}{
+		"without exemption": {
+			replicas: true,
+			strategy: true,
+		},
+		"with a justified exemption": {
+			annotations: map[string]string{
+				v1alpha1.ExemptAnnotation:                 "replicas, strategy",
+				v1alpha1.ExemptionJustificationAnnotation: "singleton consumer",
+			},
+			exempted: true,
+		},
+		"with an exemption which hasn't expired": {
+			annotations: map[string]string{
+				v1alpha1.ExemptAnnotation:                 "replicas",
+				v1alpha1.ExemptionJustificationAnnotation: "migration",
+				v1alpha1.ExemptionExpiresAnnotation:       "2018-07-01T00:00:00Z",
+			},
+			exempted: true,
+			strategy: true,
+		},
+		"with an expired exemption": {
+			annotations: map[string]string{
+				v1alpha1.ExemptAnnotation:                 "replicas",
+				v1alpha1.ExemptionJustificationAnnotation: "migration",
+				v1alpha1.ExemptionExpiresAnnotation:       "2018-05-01T00:00:00Z",
+			},
+			replicas: true,
+			strategy: true,
+		},
+		"without a justification": {
+			annotations: map[string]string{
+				v1alpha1.ExemptAnnotation: "replicas",
+			},
+			errors:   []string{"metadata.annotations[barbossa.sphc.io/exemption-justification]"},
+			replicas: true,
+			strategy: true,
+		},
+		"with an unknown section": {
+			annotations: map[string]string{
+				v1alpha1.ExemptAnnotation:                 "replicas,replica",
+				v1alpha1.ExemptionJustificationAnnotation: "typo",
+			},
+			errors:   []string{"metadata.annotations[barbossa.sphc.io/exempt]"},
+			replicas: true,
+			strategy: true,
+		},
+		"with an invalid expiry": {
+			annotations: map[string]string{
+				v1alpha1.ExemptAnnotation:                 "replicas",
+				v1alpha1.ExemptionJustificationAnnotation: "migration",
+				v1alpha1.ExemptionExpiresAnnotation:       "next week",
+			},
+			errors:   []string{"metadata.annotations[barbossa.sphc.io/exemption-expires]"},
+			replicas: true,
+			strategy: true,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			obj := metav1.ObjectMeta{
+				Name:        "web",
+				Namespace:   "default",
+				Annotations: tc.annotations,
+			}
+
+			exempted, exemption, el := policy.Exempt(obj, hap, now)
+
+			if applied := exemption != nil && !exemption.Expired(now); applied != tc.exempted {
+				t.Errorf("Expected the exemption to be applied to be %t, got %t", tc.exempted, applied)
+			}
+
+			if len(el) != len(tc.errors) {
+				t.Fatalf("Expected %d errors, got %d: %v", len(tc.errors), len(el), el)
+			}
+
+			for i, err := range el {
+				if err.Field != tc.errors[i] {
+					t.Errorf("Expected an error for %s, got %s", tc.errors[i], err.Field)
+				}
+			}
+
+			if (exempted.Spec.Replicas != nil) != tc.replicas {
+				t.Errorf("Expected the replicas section to be kept to be %t", tc.replicas)
+			}
+
+			if (exempted.Spec.Strategy != nil) != tc.strategy {
+				t.Errorf("Expected the strategy section to be kept to be %t", tc.strategy)
+			}
+
+			if exempted.Spec.Probes == nil {
+				t.Errorf("Expected the probes section to be kept")
+			}
+
+			if hap.Spec.Replicas == nil || hap.Spec.Strategy == nil {
+				t.Errorf("Expected the policy not to be modified")
+			}
+		})
+	}
+}
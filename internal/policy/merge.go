@@ -0,0 +1,93 @@
+package policy
+
+import (
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+)
+
+// Merge returns the effective policy for the given candidates, which should
+// be ordered by precedence as returned by Candidates. When the policy which
+// takes precedence has its composition set to merge, the sections it doesn't
+// configure are taken from the other candidates. A section is never combined
+// field by field, it's taken as a whole from the candidate with the highest
+// precedence which configures it. Otherwise, the policy which takes
+// precedence is returned as is.
+//
+// The merged policy keeps the metadata, weight and enforcement action of the
+// policy which takes precedence.
+func Merge(candidates []*v1alpha1.HighAvailabilityPolicy) *v1alpha1.HighAvailabilityPolicy {
+	merged, _ := merge(candidates)
+	return merged
+}
+
+// merge merges the candidates like Merge does and returns the sections each
+// candidate contributed to the merged policy.
+func merge(candidates []*v1alpha1.HighAvailabilityPolicy) (*v1alpha1.HighAvailabilityPolicy, [][]string) {
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	if candidates[0].Spec.Composition != v1alpha1.CompositionMerge {
+		return candidates[0], nil
+	}
+
+	merged := candidates[0].DeepCopy()
+	sections := make([][]string, len(candidates))
+	for i, candidate := range candidates[1:] {
+		sections[i+1] = mergeSections(&merged.Spec, &candidate.Spec)
+	}
+
+	return merged, sections
+}
+
+// mergeSections copies the sections which are configured in src but not in
+// dst. It returns the names of the sections which were copied.
+func mergeSections(dst, src *v1alpha1.HighAvailabilityPolicySpec) []string {
+	var sections []string
+
+	if dst.Replicas == nil && src.Replicas != nil {
+		dst.Replicas = src.Replicas.DeepCopy()
+		sections = append(sections, string(v1alpha1.SectionReplicas))
+	}
+
+	if dst.Strategy == nil && src.Strategy != nil {
+		dst.Strategy = src.Strategy.DeepCopy()
+		sections = append(sections, string(v1alpha1.SectionStrategy))
+	}
+
+	if dst.Resources == nil && src.Resources != nil {
+		dst.Resources = src.Resources.DeepCopy()
+		sections = append(sections, string(v1alpha1.SectionResources))
+	}
+
+	if dst.Disruptions == nil && src.Disruptions != nil {
+		dst.Disruptions = src.Disruptions.DeepCopy()
+		sections = append(sections, string(v1alpha1.SectionDisruptions))
+	}
+
+	if dst.StatefulSet == nil && src.StatefulSet != nil {
+		dst.StatefulSet = src.StatefulSet.DeepCopy()
+		sections = append(sections, string(v1alpha1.SectionStatefulSet))
+	}
+
+	if dst.DaemonSet == nil && src.DaemonSet != nil {
+		dst.DaemonSet = src.DaemonSet.DeepCopy()
+		sections = append(sections, string(v1alpha1.SectionDaemonSet))
+	}
+
+	if dst.Scheduling == nil && src.Scheduling != nil {
+		dst.Scheduling = src.Scheduling.DeepCopy()
+		sections = append(sections, string(v1alpha1.SectionScheduling))
+	}
+
+	if dst.Probes == nil && src.Probes != nil {
+		dst.Probes = src.Probes.DeepCopy()
+		sections = append(sections, string(v1alpha1.SectionProbes))
+	}
+
+	if dst.Shutdown == nil && src.Shutdown != nil {
+		dst.Shutdown = src.Shutdown.DeepCopy()
+		sections = append(sections, string(v1alpha1.SectionShutdown))
+	}
+
+	return sections
+}
@@ -0,0 +1,114 @@
+package policy_test
+
+import (
+	"testing"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+	"github.com/jelmersnoeck/barbossa/internal/policy"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMerge(t *testing.T) {
+	obj := metav1.ObjectMeta{
+		Name:      "web",
+		Namespace: "default",
+		Labels:    map[string]string{"app": "web"},
+	}
+
+	org := clusterPolicy("org", 1, map[string]string{}, nil)
+	org.Spec.Replicas = &v1alpha1.HighAvailabilityPolicyReplicas{Minimum: 3}
+	org.Spec.Strategy = &v1alpha1.HighAvailabilityPolicyStrategy{}
+	org.Spec.Resources = &v1alpha1.HighAvailabilityPolicyResourceRequirements{}
+
+	team := namespacedPolicy("team", 10, map[string]string{"app": "web"})
+	team.Spec.EnforcementAction = v1alpha1.EnforcementActionWarn
+	team.Spec.Replicas = &v1alpha1.HighAvailabilityPolicyReplicas{Minimum: 2}
+	team.Spec.Probes = &v1alpha1.HighAvailabilityPolicyProbes{}
+
+	t.Run("without composition", func(t *testing.T) {
+		candidates, err := policy.Candidates(obj, nil, []*v1alpha1.HighAvailabilityPolicy{team}, []*v1alpha1.ClusterHighAvailabilityPolicy{org})
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if merged := policy.Merge(candidates); merged != team {
+			t.Errorf("Expected the policy which takes precedence to be returned as is")
+		}
+
+		expected := "HighAvailabilityPolicy default:team, selected over ClusterHighAvailabilityPolicy org (lower weight)"
+		if explanation := policy.Explain(candidates); explanation != expected {
+			t.Errorf("Expected\n%s\nbut got\n%s", expected, explanation)
+		}
+	})
+
+	t.Run("with merge composition", func(t *testing.T) {
+		team := team.DeepCopy()
+		team.Spec.Composition = v1alpha1.CompositionMerge
+
+		other := namespacedPolicy("other", 5, map[string]string{})
+		other.Spec.Strategy = &v1alpha1.HighAvailabilityPolicyStrategy{
+			MinReadySeconds: &v1alpha1.Bounds{Minimum: int32Ptr(10)},
+		}
+
+		haps := []*v1alpha1.HighAvailabilityPolicy{
+			team,
+			other,
+			namespacedPolicy("empty", 5, map[string]string{}),
+		}
+		chaps := []*v1alpha1.ClusterHighAvailabilityPolicy{org}
+
+		candidates, err := policy.Candidates(obj, nil, haps, chaps)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		merged := policy.Merge(candidates)
+		if merged.Name != "team" || merged.Spec.EnforcementAction != v1alpha1.EnforcementActionWarn {
+			t.Errorf("Expected the merged policy to keep the metadata and enforcement action of the selected policy")
+		}
+
+		if merged.Spec.Replicas.Minimum != 2 {
+			t.Errorf("Expected the replicas of the selected policy to be used, got minimum %d", merged.Spec.Replicas.Minimum)
+		}
+
+		if merged.Spec.Strategy == nil || merged.Spec.Strategy.MinReadySeconds == nil {
+			t.Errorf("Expected the strategy of the policy with the higher weight to be used")
+		}
+
+		if merged.Spec.Resources == nil || merged.Spec.Probes == nil {
+			t.Errorf("Expected the resources and probes to be merged")
+		}
+
+		if team.Spec.Strategy != nil || other.Spec.Resources != nil {
+			t.Errorf("Expected the candidates not to be modified")
+		}
+
+		expected := "HighAvailabilityPolicy default:team, merged with " +
+			"HighAvailabilityPolicy default:other (strategy), " +
+			"ClusterHighAvailabilityPolicy org (resources), " +
+			"selected over HighAvailabilityPolicy default:empty (lower weight)"
+		if explanation := policy.Explain(candidates); explanation != expected {
+			t.Errorf("Expected\n%s\nbut got\n%s", expected, explanation)
+		}
+
+		selected, err := policy.Select(obj, nil, haps, chaps)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if selected.Spec.Resources == nil {
+			t.Errorf("Expected Select to return the merged policy")
+		}
+	})
+
+	t.Run("without candidates", func(t *testing.T) {
+		if merged := policy.Merge(nil); merged != nil {
+			t.Errorf("Expected no policy, got %s", policy.Describe(merged))
+		}
+	})
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
@@ -0,0 +1,208 @@
+// Package policy selects the policy a workload should be validated against
+// from the HighAvailabilityPolicies in its namespace and the
+// ClusterHighAvailabilityPolicies in the cluster.
+package policy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ClusterPolicyKind is the Kind set on the policies which are converted from
+// a ClusterHighAvailabilityPolicy, this allows us to tell them apart from
+// namespaced policies.
+const ClusterPolicyKind = "ClusterHighAvailabilityPolicy"
+
+// Select selects the policy which takes precedence over all other policies
+// which select the given object, see Candidates for the order. When that
+// policy merges the other policies, the merged policy is returned, see Merge.
+// When no policy selects the object, nil is returned.
+func Select(
+	obj metav1.ObjectMeta,
+	namespace labels.Set,
+	haps []*v1alpha1.HighAvailabilityPolicy,
+	chaps []*v1alpha1.ClusterHighAvailabilityPolicy,
+) (*v1alpha1.HighAvailabilityPolicy, error) {
+	candidates, err := Candidates(obj, namespace, haps, chaps)
+	if err != nil || len(candidates) == 0 {
+		return nil, err
+	}
+
+	return Merge(candidates), nil
+}
+
+// Candidates returns all the policies which select the given object, ordered
+// by precedence. A ClusterHighAvailabilityPolicy only applies when its
+// namespace selector matches the labels of the object's namespace. The order
+// doesn't depend on the order in which the policies are listed, policies are
+// ordered by:
+//
+// 1. their weight, the highest weight first;
+// 2. the specificity of their selector, the selector with the most
+// requirements first;
+// 3. their scope, namespaced policies first as they're configured closer to
+// the object;
+// 4. their name, in alphabetical order.
+func Candidates(
+	obj metav1.ObjectMeta,
+	namespace labels.Set,
+	haps []*v1alpha1.HighAvailabilityPolicy,
+	chaps []*v1alpha1.ClusterHighAvailabilityPolicy,
+) ([]*v1alpha1.HighAvailabilityPolicy, error) {
+	candidates := []*v1alpha1.HighAvailabilityPolicy{}
+
+	for _, chap := range chaps {
+		nsMatches, err := selects(chap.Spec.NamespaceSelector, namespace, true)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace selector for %s %s: %s", ClusterPolicyKind, chap.Name, err)
+		}
+
+		if !nsMatches {
+			continue
+		}
+
+		matches, err := selects(chap.Spec.Selector, obj.Labels, false)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector for %s %s: %s", ClusterPolicyKind, chap.Name, err)
+		}
+
+		if matches {
+			candidates = append(candidates, FromCluster(chap))
+		}
+	}
+
+	for _, hap := range haps {
+		matches, err := selects(hap.Spec.Selector, obj.Labels, false)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector for HighAvailabilityPolicy %s:%s: %s", hap.Namespace, hap.Name, err)
+		}
+
+		if matches {
+			candidates = append(candidates, hap)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return precedes(candidates[i], candidates[j]) != ""
+	})
+
+	return candidates, nil
+}
+
+// Explain describes the policy which was selected and the reason it was
+// selected over the other candidates. When the selected policy merges the
+// other candidates, the sections they contributed are listed as well. The
+// candidates should be ordered by precedence, as returned by Candidates.
+func Explain(candidates []*v1alpha1.HighAvailabilityPolicy) string {
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	selected := candidates[0]
+	if len(candidates) == 1 {
+		return Describe(selected)
+	}
+
+	_, sections := merge(candidates)
+
+	var merged, others []string
+	for i, candidate := range candidates[1:] {
+		if sections != nil && len(sections[i+1]) > 0 {
+			merged = append(merged, fmt.Sprintf("%s (%s)", Describe(candidate), strings.Join(sections[i+1], ", ")))
+			continue
+		}
+
+		others = append(others, fmt.Sprintf("%s (%s)", Describe(candidate), precedes(selected, candidate)))
+	}
+
+	explanation := Describe(selected)
+	if len(merged) > 0 {
+		explanation = fmt.Sprintf("%s, merged with %s", explanation, strings.Join(merged, ", "))
+	}
+
+	if len(others) > 0 {
+		explanation = fmt.Sprintf("%s, selected over %s", explanation, strings.Join(others, ", "))
+	}
+
+	return explanation
+}
+
+// precedes checks if policy a takes precedence over policy b. It returns why
+// policy b lost, or an empty string when policy a doesn't take precedence.
+func precedes(a, b *v1alpha1.HighAvailabilityPolicy) string {
+	if a.Spec.Weight != b.Spec.Weight {
+		return reason(a.Spec.Weight > b.Spec.Weight, "lower weight")
+	}
+
+	if aSpec, bSpec := specificity(a.Spec.Selector), specificity(b.Spec.Selector); aSpec != bSpec {
+		return reason(aSpec > bSpec, "less specific selector")
+	}
+
+	if aCluster, bCluster := a.Kind == ClusterPolicyKind, b.Kind == ClusterPolicyKind; aCluster != bCluster {
+		return reason(bCluster, "cluster policy")
+	}
+
+	return reason(a.Name < b.Name, "later name")
+}
+
+func reason(ok bool, why string) string {
+	if !ok {
+		return ""
+	}
+
+	return why
+}
+
+// specificity is the number of requirements of a selector.
+func specificity(selector *metav1.LabelSelector) int {
+	if selector == nil {
+		return 0
+	}
+
+	return len(selector.MatchLabels) + len(selector.MatchExpressions)
+}
+
+// FromCluster converts a ClusterHighAvailabilityPolicy into a
+// HighAvailabilityPolicy so it can be validated against. The result keeps the
+// name of the cluster policy and has the ClusterPolicyKind set.
+func FromCluster(chap *v1alpha1.ClusterHighAvailabilityPolicy) *v1alpha1.HighAvailabilityPolicy {
+	return &v1alpha1.HighAvailabilityPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       ClusterPolicyKind,
+			APIVersion: chap.APIVersion,
+		},
+		ObjectMeta: *chap.ObjectMeta.DeepCopy(),
+		Spec:       *chap.Spec.HighAvailabilityPolicySpec.DeepCopy(),
+	}
+}
+
+// selects checks if the selector matches the given labels. A missing
+// selector matches depending on the given default.
+func selects(selector *metav1.LabelSelector, lbls labels.Set, def bool) (bool, error) {
+	if selector == nil {
+		return def, nil
+	}
+
+	lblSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, err
+	}
+
+	return lblSelector.Matches(lbls), nil
+}
+
+// Describe returns a human readable reference to the given policy which tells
+// namespaced and cluster policies apart.
+func Describe(hap *v1alpha1.HighAvailabilityPolicy) string {
+	if hap.Kind == ClusterPolicyKind {
+		return fmt.Sprintf("%s %s", ClusterPolicyKind, hap.Name)
+	}
+
+	return fmt.Sprintf("HighAvailabilityPolicy %s:%s", hap.Namespace, hap.Name)
+}
@@ -0,0 +1,204 @@
+package policy_test
+
+import (
+	"testing"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+	"github.com/jelmersnoeck/barbossa/internal/policy"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestSelect(t *testing.T) {
+	obj := metav1.ObjectMeta{
+		Name:      "web",
+		Namespace: "default",
+		Labels:    map[string]string{"app": "web"},
+	}
+	namespace := labels.Set{"team": "platform"}
+
+	tcs := map[string]struct {
+		haps     []*v1alpha1.HighAvailabilityPolicy
+		chaps    []*v1alpha1.ClusterHighAvailabilityPolicy
+		expected string
+	}{
+		"without any policies": {
+			expected: "",
+		},
+		"with policies which don't select the object": {
+			haps: []*v1alpha1.HighAvailabilityPolicy{
+				namespacedPolicy("other", 10, map[string]string{"app": "other"}),
+			},
+			chaps: []*v1alpha1.ClusterHighAvailabilityPolicy{
+				clusterPolicy("other", 10, map[string]string{"app": "other"}, nil),
+			},
+			expected: "",
+		},
+		"with multiple namespaced policies": {
+			haps: []*v1alpha1.HighAvailabilityPolicy{
+				namespacedPolicy("low", 1, map[string]string{"app": "web"}),
+				namespacedPolicy("high", 10, map[string]string{}),
+				namespacedPolicy("medium", 5, map[string]string{"app": "web"}),
+			},
+			expected: "HighAvailabilityPolicy default:high",
+		},
+		"with a cluster policy for all namespaces": {
+			chaps: []*v1alpha1.ClusterHighAvailabilityPolicy{
+				clusterPolicy("all", 1, map[string]string{"app": "web"}, nil),
+			},
+			expected: "ClusterHighAvailabilityPolicy all",
+		},
+		"with a cluster policy for other namespaces": {
+			chaps: []*v1alpha1.ClusterHighAvailabilityPolicy{
+				clusterPolicy("other-team", 10, map[string]string{}, map[string]string{"team": "other"}),
+				clusterPolicy("platform", 1, map[string]string{}, map[string]string{"team": "platform"}),
+			},
+			expected: "ClusterHighAvailabilityPolicy platform",
+		},
+		"with a cluster policy with a higher weight": {
+			haps: []*v1alpha1.HighAvailabilityPolicy{
+				namespacedPolicy("namespaced", 1, map[string]string{}),
+			},
+			chaps: []*v1alpha1.ClusterHighAvailabilityPolicy{
+				clusterPolicy("cluster", 10, map[string]string{}, nil),
+			},
+			expected: "ClusterHighAvailabilityPolicy cluster",
+		},
+		"with a namespaced and cluster policy of equal weight": {
+			haps: []*v1alpha1.HighAvailabilityPolicy{
+				namespacedPolicy("namespaced", 10, map[string]string{}),
+			},
+			chaps: []*v1alpha1.ClusterHighAvailabilityPolicy{
+				clusterPolicy("cluster", 10, map[string]string{}, nil),
+			},
+			expected: "HighAvailabilityPolicy default:namespaced",
+		},
+		"with policies of equal weight and different selectors": {
+			haps: []*v1alpha1.HighAvailabilityPolicy{
+				namespacedPolicy("specific", 10, map[string]string{"app": "web"}),
+				namespacedPolicy("all", 10, map[string]string{}),
+			},
+			expected: "HighAvailabilityPolicy default:specific",
+		},
+		"with a more specific cluster policy of equal weight": {
+			haps: []*v1alpha1.HighAvailabilityPolicy{
+				namespacedPolicy("namespaced", 10, map[string]string{}),
+			},
+			chaps: []*v1alpha1.ClusterHighAvailabilityPolicy{
+				clusterPolicy("cluster", 10, map[string]string{"app": "web"}, nil),
+			},
+			expected: "ClusterHighAvailabilityPolicy cluster",
+		},
+		"with equal policies": {
+			haps: []*v1alpha1.HighAvailabilityPolicy{
+				namespacedPolicy("b", 10, map[string]string{"app": "web"}),
+				namespacedPolicy("a", 10, map[string]string{"app": "web"}),
+				namespacedPolicy("c", 10, map[string]string{"app": "web"}),
+			},
+			expected: "HighAvailabilityPolicy default:a",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			hap, err := policy.Select(obj, namespace, tc.haps, tc.chaps)
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			if tc.expected == "" {
+				if hap != nil {
+					t.Errorf("Expected no policy, got %s", policy.Describe(hap))
+				}
+				return
+			}
+
+			if hap == nil {
+				t.Fatalf("Expected %s, got no policy", tc.expected)
+			}
+
+			if desc := policy.Describe(hap); desc != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, desc)
+			}
+		})
+	}
+
+	t.Run("with an invalid namespace selector", func(t *testing.T) {
+		chap := clusterPolicy("invalid", 1, map[string]string{}, nil)
+		chap.Spec.NamespaceSelector = &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: "Unknown"},
+			},
+		}
+
+		if _, err := policy.Select(obj, namespace, nil, []*v1alpha1.ClusterHighAvailabilityPolicy{chap}); err == nil {
+			t.Errorf("Expected an error for an invalid namespace selector")
+		}
+	})
+}
+
+func TestExplain(t *testing.T) {
+	obj := metav1.ObjectMeta{
+		Name:      "web",
+		Namespace: "default",
+		Labels:    map[string]string{"app": "web"},
+	}
+
+	haps := []*v1alpha1.HighAvailabilityPolicy{
+		namespacedPolicy("web", 10, map[string]string{}),
+		namespacedPolicy("low", 1, map[string]string{"app": "web"}),
+		namespacedPolicy("specific", 10, map[string]string{"app": "web"}),
+		namespacedPolicy("other", 10, map[string]string{"app": "other"}),
+		namespacedPolicy("another", 10, map[string]string{}),
+	}
+	chaps := []*v1alpha1.ClusterHighAvailabilityPolicy{
+		clusterPolicy("cluster", 10, map[string]string{}, nil),
+	}
+
+	candidates, err := policy.Candidates(obj, nil, haps, chaps)
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	expected := "HighAvailabilityPolicy default:specific, selected over " +
+		"HighAvailabilityPolicy default:another (less specific selector), " +
+		"HighAvailabilityPolicy default:web (less specific selector), " +
+		"ClusterHighAvailabilityPolicy cluster (less specific selector), " +
+		"HighAvailabilityPolicy default:low (lower weight)"
+	if explanation := policy.Explain(candidates); explanation != expected {
+		t.Errorf("Expected\n%s\nbut got\n%s", expected, explanation)
+	}
+
+	if explanation := policy.Explain(candidates[:1]); explanation != "HighAvailabilityPolicy default:specific" {
+		t.Errorf("Expected a single candidate to only be described, got %s", explanation)
+	}
+}
+
+func namespacedPolicy(name string, weight int, selector map[string]string) *v1alpha1.HighAvailabilityPolicy {
+	return &v1alpha1.HighAvailabilityPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1alpha1.HighAvailabilityPolicySpec{
+			Weight:   weight,
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+		},
+	}
+}
+
+func clusterPolicy(name string, weight int, selector, nsSelector map[string]string) *v1alpha1.ClusterHighAvailabilityPolicy {
+	chap := &v1alpha1.ClusterHighAvailabilityPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.ClusterHighAvailabilityPolicySpec{
+			HighAvailabilityPolicySpec: v1alpha1.HighAvailabilityPolicySpec{
+				Weight:   weight,
+				Selector: &metav1.LabelSelector{MatchLabels: selector},
+			},
+		},
+	}
+
+	if nsSelector != nil {
+		chap.Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: nsSelector}
+	}
+
+	return chap
+}
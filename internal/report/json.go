@@ -0,0 +1,23 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type jsonReport struct {
+	Workloads  int         `json:"workloads"`
+	Violating  int         `json:"violating"`
+	Violations []Violation `json:"violations"`
+}
+
+func writeJSON(w io.Writer, results []Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(jsonReport{
+		Workloads:  len(results),
+		Violating:  Violating(results),
+		Violations: Violations(results),
+	})
+}
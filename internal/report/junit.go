@@ -0,0 +1,80 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// The JUnit format has a test case for every workload, workloads which violate
+// their policy have a failure which lists the violations.
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Content string `xml:",chardata"`
+}
+
+func writeJUnit(w io.Writer, results []Result) error {
+	suite := junitTestSuite{
+		Name:     "barbossa",
+		Tests:    len(results),
+		Failures: Violating(results),
+		Cases:    []junitTestCase{},
+	}
+
+	for _, result := range results {
+		tc := junitTestCase{
+			ClassName: result.Policy,
+			Name:      Describe(result.Workload),
+			File:      result.Source,
+		}
+
+		if len(result.Errors) > 0 {
+			lines := make([]string, len(result.Errors))
+			for i, err := range result.Errors {
+				lines[i] = fmt.Sprintf("%s: %s (value: %s)", err.Field, err.Detail, formatValue(err.BadValue))
+			}
+
+			tc.Failure = &junitFailure{
+				Message: fmt.Sprintf("%s violates %s", Describe(result.Workload), result.Policy),
+				Type:    "PolicyViolation",
+				Content: strings.Join(lines, "\n"),
+			}
+		}
+
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(junitTestSuites{Suites: []junitTestSuite{suite}}); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
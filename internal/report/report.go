@@ -0,0 +1,182 @@
+// Package report renders the results of validating workloads against their
+// policy in formats which can be read by humans and tooling: plain text, JSON,
+// JUnit XML and SARIF.
+package report
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Format is the format a report is rendered in.
+type Format string
+
+// The formats a report can be rendered in.
+const (
+	FormatText  Format = "text"
+	FormatJSON  Format = "json"
+	FormatJUnit Format = "junit"
+	FormatSARIF Format = "sarif"
+)
+
+// Formats lists all the supported formats.
+var Formats = []Format{FormatText, FormatJSON, FormatJUnit, FormatSARIF}
+
+// Result is the outcome of validating a single workload against the policy
+// which selects it. A workload without errors complies with its policy.
+type Result struct {
+	// Source is where the workload was read from, for example the file of a
+	// manifest. It's empty for workloads read from a cluster.
+	Source string
+
+	Workload v1.ObjectReference
+
+	// Policy describes the policy the workload was validated against.
+	Policy string
+
+	Errors field.ErrorList
+}
+
+// Violation is a single violation of a policy by a workload.
+type Violation struct {
+	Policy   string             `json:"policy"`
+	Workload v1.ObjectReference `json:"workload"`
+	Source   string             `json:"source,omitempty"`
+	Field    string             `json:"field"`
+	Type     field.ErrorType    `json:"type"`
+	BadValue interface{}        `json:"badValue,omitempty"`
+	Message  string             `json:"message"`
+}
+
+// Violations flattens the errors of the results into a list of violations.
+func Violations(results []Result) []Violation {
+	violations := []Violation{}
+
+	for _, result := range results {
+		for _, err := range result.Errors {
+			violations = append(violations, Violation{
+				Policy:   result.Policy,
+				Workload: result.Workload,
+				Source:   result.Source,
+				Field:    err.Field,
+				Type:     err.Type,
+				BadValue: badValue(err.BadValue),
+				Message:  err.Detail,
+			})
+		}
+	}
+
+	return violations
+}
+
+// Write renders the results in the given format.
+func Write(w io.Writer, format Format, results []Result) error {
+	switch format {
+	case FormatText:
+		return writeText(w, results)
+	case FormatJSON:
+		return writeJSON(w, results)
+	case FormatJUnit:
+		return writeJUnit(w, results)
+	case FormatSARIF:
+		return writeSARIF(w, results)
+	}
+
+	return fmt.Errorf("unsupported report format '%s', use one of %v", format, Formats)
+}
+
+// Violating counts the results which have errors.
+func Violating(results []Result) int {
+	count := 0
+	for _, result := range results {
+		if len(result.Errors) > 0 {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Describe returns a human readable reference to the workload.
+func Describe(ref v1.ObjectReference) string {
+	return fmt.Sprintf("%s %s:%s", ref.Kind, ref.Namespace, ref.Name)
+}
+
+func writeText(w io.Writer, results []Result) error {
+	for _, result := range results {
+		if len(result.Errors) == 0 {
+			continue
+		}
+
+		source := ""
+		if result.Source != "" {
+			source = fmt.Sprintf(" (%s)", result.Source)
+		}
+
+		if _, err := fmt.Fprintf(w, "%s%s violates %s:\n", Describe(result.Workload), source, result.Policy); err != nil {
+			return err
+		}
+
+		for _, verr := range result.Errors {
+			if _, err := fmt.Fprintf(w, "  %s\n", verr); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "Validated %d workloads, %d violate their policy\n", len(results), Violating(results))
+	return err
+}
+
+// badValue dereferences pointers the same way field.Error does when it's
+// formatted, so the value can be rendered.
+func badValue(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Ptr {
+		return value
+	}
+
+	if rv.IsNil() {
+		return nil
+	}
+
+	return rv.Elem().Interface()
+}
+
+// formatValue renders a bad value as a string, labels and other maps are
+// rendered with their keys sorted so the output is stable.
+func formatValue(value interface{}) string {
+	value = badValue(value)
+	if value == nil {
+		return "null"
+	}
+
+	if lbls, ok := value.(map[string]string); ok {
+		keys := make([]string, 0, len(lbls))
+		for k := range lbls {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		out := ""
+		for i, k := range keys {
+			if i > 0 {
+				out += ","
+			}
+			out += k + "=" + lbls[k]
+		}
+
+		return out
+	}
+
+	return fmt.Sprintf("%v", value)
+}
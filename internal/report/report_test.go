@@ -0,0 +1,196 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/jelmersnoeck/barbossa/internal/report"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func testResults() []report.Result {
+	replicas := int32(1)
+
+	return []report.Result{
+		{
+			Source:   "deploy/web.yaml",
+			Workload: v1.ObjectReference{Kind: "Deployment", APIVersion: "apps/v1", Namespace: "default", Name: "web"},
+			Policy:   "HighAvailabilityPolicy default:default",
+			Errors: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "replicas"), &replicas, "should be at least 2"),
+				field.Invalid(field.NewPath("spec", "template", "metadata", "labels"), map[string]string{"b": "2", "a": "1"}, "should be selected by a PodDisruptionBudget"),
+			},
+		},
+		{
+			Source:   "deploy/api.yaml",
+			Workload: v1.ObjectReference{Kind: "Deployment", APIVersion: "apps/v1", Namespace: "default", Name: "api"},
+			Policy:   "HighAvailabilityPolicy default:default",
+		},
+	}
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("text", func(t *testing.T) {
+		results := testResults()
+
+		buf := &bytes.Buffer{}
+		if err := report.Write(buf, report.FormatText, results); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := "Deployment default:web (deploy/web.yaml) violates HighAvailabilityPolicy default:default:\n" +
+			"  spec.replicas: Invalid value: 1: should be at least 2\n" +
+			"  " + results[0].Errors[1].Error() + "\n" +
+			"Validated 2 workloads, 1 violate their policy\n"
+		if buf.String() != expected {
+			t.Errorf("Expected\n%s\nbut got\n%s", expected, buf.String())
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		if err := report.Write(buf, report.FormatJSON, testResults()); err != nil {
+			t.Fatal(err)
+		}
+
+		out := struct {
+			Workloads  int                      `json:"workloads"`
+			Violating  int                      `json:"violating"`
+			Violations []map[string]interface{} `json:"violations"`
+		}{}
+		if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+			t.Fatal(err)
+		}
+
+		if out.Workloads != 2 || out.Violating != 1 || len(out.Violations) != 2 {
+			t.Fatalf("Expected 2 workloads with 1 violating and 2 violations, got %s", buf.String())
+		}
+
+		violation := out.Violations[0]
+		if violation["field"] != "spec.replicas" || violation["badValue"] != float64(1) || violation["message"] != "should be at least 2" {
+			t.Errorf("Expected the replicas violation, got %v", violation)
+		}
+
+		if violation["policy"] != "HighAvailabilityPolicy default:default" || violation["source"] != "deploy/web.yaml" {
+			t.Errorf("Expected the policy and source to be set, got %v", violation)
+		}
+
+		workload, _ := violation["workload"].(map[string]interface{})
+		if workload["name"] != "web" || workload["namespace"] != "default" {
+			t.Errorf("Expected the workload reference to be set, got %v", violation["workload"])
+		}
+	})
+
+	t.Run("junit", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		if err := report.Write(buf, report.FormatJUnit, testResults()); err != nil {
+			t.Fatal(err)
+		}
+
+		out := struct {
+			Suites []struct {
+				Tests    int `xml:"tests,attr"`
+				Failures int `xml:"failures,attr"`
+				Cases    []struct {
+					Name    string `xml:"name,attr"`
+					Failure *struct {
+						Content string `xml:",chardata"`
+					} `xml:"failure"`
+				} `xml:"testcase"`
+			} `xml:"testsuite"`
+		}{}
+		if err := xml.Unmarshal(buf.Bytes(), &out); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(out.Suites) != 1 || out.Suites[0].Tests != 2 || out.Suites[0].Failures != 1 {
+			t.Fatalf("Expected a suite with 2 tests and 1 failure, got %s", buf.String())
+		}
+
+		cases := out.Suites[0].Cases
+		if cases[0].Failure == nil || cases[1].Failure != nil {
+			t.Fatalf("Expected only the first test case to fail, got %s", buf.String())
+		}
+
+		expected := "spec.template.metadata.labels: should be selected by a PodDisruptionBudget (value: a=1,b=2)"
+		if !strings.Contains(cases[0].Failure.Content, expected) {
+			t.Errorf("Expected the failure to contain %q, got %q", expected, cases[0].Failure.Content)
+		}
+	})
+
+	t.Run("sarif", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		if err := report.Write(buf, report.FormatSARIF, testResults()); err != nil {
+			t.Fatal(err)
+		}
+
+		out := struct {
+			Version string `json:"version"`
+			Runs    []struct {
+				Tool struct {
+					Driver struct {
+						Rules []struct {
+							ID string `json:"id"`
+						} `json:"rules"`
+					} `json:"driver"`
+				} `json:"tool"`
+				Results []struct {
+					RuleID    string `json:"ruleId"`
+					Locations []struct {
+						PhysicalLocation struct {
+							ArtifactLocation struct {
+								URI string `json:"uri"`
+							} `json:"artifactLocation"`
+						} `json:"physicalLocation"`
+						LogicalLocations []struct {
+							FullyQualifiedName string `json:"fullyQualifiedName"`
+						} `json:"logicalLocations"`
+					} `json:"locations"`
+				} `json:"results"`
+			} `json:"runs"`
+		}{}
+		if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+			t.Fatal(err)
+		}
+
+		if out.Version != "2.1.0" || len(out.Runs) != 1 {
+			t.Fatalf("Expected a single SARIF 2.1.0 run, got %s", buf.String())
+		}
+
+		run := out.Runs[0]
+		rules := run.Tool.Driver.Rules
+		if len(rules) != 2 || rules[0].ID != "replicas" || rules[1].ID != "template.metadata.labels" {
+			t.Errorf("Expected a rule for every field, got %v", rules)
+		}
+
+		if len(run.Results) != 2 {
+			t.Fatalf("Expected 2 results, got %d", len(run.Results))
+		}
+
+		for i, result := range run.Results {
+			if result.RuleID != rules[i].ID {
+				t.Errorf("Expected result %d to reference rule %s, got %s", i, rules[i].ID, result.RuleID)
+			}
+		}
+
+		location := run.Results[0].Locations[0]
+		if location.PhysicalLocation.ArtifactLocation.URI != "deploy/web.yaml" {
+			t.Errorf("Expected the source as location, got %s", location.PhysicalLocation.ArtifactLocation.URI)
+		}
+
+		if location.LogicalLocations[0].FullyQualifiedName != "Deployment/default/web/spec.replicas" {
+			t.Errorf("Expected the field as logical location, got %s", location.LogicalLocations[0].FullyQualifiedName)
+		}
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		if err := report.Write(&bytes.Buffer{}, report.Format("yaml"), testResults()); err == nil {
+			t.Errorf("Expected an error for an unsupported format")
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// The SARIF format is described in the OASIS Static Analysis Results
+// Interchange Format 2.1.0 specification. We only use the parts of it which
+// are needed to report violations to code scanning tools.
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+// subscripts matches the indices and keys in a field path.
+var subscripts = regexp.MustCompile(`\[[^\]]*\]`)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID     string                 `json:"ruleId"`
+	Level      string                 `json:"level"`
+	Message    sarifMessage           `json:"message"`
+	Locations  []sarifLocation        `json:"locations"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+func writeSARIF(w io.Writer, results []Result) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "barbossa",
+				InformationURI: "https://github.com/jelmersnoeck/barbossa",
+				Rules:          []sarifRule{},
+			},
+		},
+		Results: []sarifResult{},
+	}
+
+	// every field which is validated is a rule, the policy, workload and
+	// container are specific to the result.
+	rules := map[string]bool{}
+	for _, v := range Violations(results) {
+		ruleID := sarifRuleID(v)
+		if !rules[ruleID] {
+			rules[ruleID] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+				ID:               ruleID,
+				ShortDescription: sarifMessage{Text: fmt.Sprintf("The %s of a workload should comply with its policy", ruleID)},
+			})
+		}
+
+		location := sarifLocation{
+			LogicalLocations: []sarifLogicalLocation{
+				{
+					Name:               v.Field,
+					FullyQualifiedName: strings.Join([]string{v.Workload.Kind, v.Workload.Namespace, v.Workload.Name, v.Field}, "/"),
+					Kind:               "member",
+				},
+			},
+		}
+
+		if v.Source != "" {
+			location.PhysicalLocation = &sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: v.Source},
+			}
+		}
+
+		run.Results = append(run.Results, sarifResult{
+			RuleID: ruleID,
+			Level:  "error",
+			Message: sarifMessage{
+				Text: fmt.Sprintf("%s violates %s: %s: %s", Describe(v.Workload), v.Policy, v.Field, v.Message),
+			},
+			Locations: []sarifLocation{location},
+			Properties: map[string]interface{}{
+				"policy":   v.Policy,
+				"workload": v.Workload,
+				"field":    v.Field,
+				"badValue": formatValue(v.BadValue),
+			},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	})
+}
+
+// sarifRuleID derives the rule of a violation from its field, without the
+// indices and keys so the violations of every container or annotation share
+// their rule. The fields of the spec are relative to the spec and the fields
+// of the pod are relative to the pod spec, for example replicas,
+// strategy.type or containers.resources.requests.cpu.
+func sarifRuleID(v Violation) string {
+	path := subscripts.ReplaceAllString(v.Field, "")
+	for _, prefix := range []string{"spec.template.spec.", "spec."} {
+		if strings.HasPrefix(path, prefix) {
+			path = strings.TrimPrefix(path, prefix)
+			break
+		}
+	}
+
+	if path == "" {
+		return string(v.Type)
+	}
+
+	return path
+}
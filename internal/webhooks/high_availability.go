@@ -2,33 +2,37 @@ package webhooks
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
 	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1/validation"
-	"github.com/jelmersnoeck/barbossa/pkg/client/generated/clientset/versioned"
+	"github.com/jelmersnoeck/barbossa/internal/policy"
+	"github.com/jelmersnoeck/barbossa/internal/workloads"
 
 	"k8s.io/api/admission/v1beta1"
-	ev1beta1 "k8s.io/api/extensions/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
 )
 
 type HighAvailabilityAdmissionHook struct {
-	crdClient versioned.Interface
+	// Informers are the informers shared with the other admission hooks.
+	Informers *Informers
 }
 
 func (h *HighAvailabilityAdmissionHook) Initialize(cfg *rest.Config, stopCh <-chan struct{}) error {
-	crdClient, err := versioned.NewForConfig(cfg)
-	if err != nil {
-		return err
-	}
-
-	h.crdClient = crdClient
-	return nil
+	return h.Informers.Initialize(cfg, stopCh)
 }
 
 func (h *HighAvailabilityAdmissionHook) ValidatingResource() (plural schema.GroupVersionResource, singular string) {
@@ -38,89 +42,467 @@ func (h *HighAvailabilityAdmissionHook) ValidatingResource() (plural schema.Grou
 }
 
 func (h *HighAvailabilityAdmissionHook) Validate(ar *v1beta1.AdmissionRequest) *v1beta1.AdmissionResponse {
-	var dpl ev1beta1.Deployment
-	if err := json.Unmarshal(ar.Object.Raw, &dpl); err != nil {
-		return &v1beta1.AdmissionResponse{
-			Allowed: false,
-			Result: &metav1.Status{
-				Status:  metav1.StatusFailure,
-				Code:    http.StatusBadRequest,
-				Reason:  metav1.StatusReasonBadRequest,
-				Message: err.Error(),
-			},
+	gvk := schema.GroupVersionKind{Group: ar.Kind.Group, Version: ar.Kind.Version, Kind: ar.Kind.Kind}
+
+	switch {
+	case workloads.IsDeployment(gvk):
+		dpl, err := workloads.DecodeDeployment(gvk, ar.Object.Raw)
+		if err != nil {
+			return badRequest(err)
+		}
+
+		var old *appsv1.Deployment
+		if ar.Operation == v1beta1.Update {
+			if old, err = workloads.DecodeDeployment(gvk, ar.OldObject.Raw); err != nil {
+				return badRequest(err)
+			}
+		}
+
+		return h.validateDeployment(dpl, old)
+	case workloads.IsStatefulSet(gvk):
+		sts, err := workloads.DecodeStatefulSet(gvk, ar.Object.Raw)
+		if err != nil {
+			return badRequest(err)
+		}
+
+		var old *appsv1.StatefulSet
+		if ar.Operation == v1beta1.Update {
+			if old, err = workloads.DecodeStatefulSet(gvk, ar.OldObject.Raw); err != nil {
+				return badRequest(err)
+			}
+		}
+
+		return h.validateStatefulSet(sts, old)
+	case workloads.IsDaemonSet(gvk):
+		ds, err := workloads.DecodeDaemonSet(gvk, ar.Object.Raw)
+		if err != nil {
+			return badRequest(err)
+		}
+
+		var old *appsv1.DaemonSet
+		if ar.Operation == v1beta1.Update {
+			if old, err = workloads.DecodeDaemonSet(gvk, ar.OldObject.Raw); err != nil {
+				return badRequest(err)
+			}
+		}
+
+		return h.validateDaemonSet(ds, old)
+	case workloads.IsHorizontalPodAutoscaler(gvk):
+		hpa, err := workloads.DecodeHorizontalPodAutoscaler(gvk, ar.Object.Raw)
+		if err != nil {
+			return badRequest(err)
+		}
+
+		return h.validateHorizontalPodAutoscaler(hpa)
+	case gvk == policyv1beta1.SchemeGroupVersion.WithKind("PodDisruptionBudget"):
+		pdb := &policyv1beta1.PodDisruptionBudget{}
+		if err := json.Unmarshal(ar.Object.Raw, pdb); err != nil {
+			return badRequest(err)
 		}
+
+		return h.validatePodDisruptionBudget(pdb)
+	}
+
+	// we don't know how to validate this resource, let it through.
+	log.Printf("Skipping validation for unsupported kind %s", gvk)
+	return &v1beta1.AdmissionResponse{
+		Allowed: true,
 	}
+}
 
-	hapList, err := h.crdClient.Barbossa().
-		HighAvailabilityPolicies(dpl.Namespace).List(metav1.ListOptions{})
+// validateDeployment validates the Deployment against the policy which selects
+// it. When the Deployment is updated, the old Deployment is given so the
+// violations it already had can be ignored when the policy allows it.
+func (h *HighAvailabilityAdmissionHook) validateDeployment(dpl, old *appsv1.Deployment) *v1beta1.AdmissionResponse {
+	candidates, exceptions, err := h.Informers.policies.Candidates(dpl.ObjectMeta)
 	if err != nil {
+		return internalError(err)
+	}
+
+	// no hap which selects this resource, ignore it!
+	if len(candidates) == 0 {
 		return &v1beta1.AdmissionResponse{
-			Allowed: false,
-			Result: &metav1.Status{
-				Status:  metav1.StatusFailure,
-				Code:    http.StatusInternalServerError,
-				Reason:  metav1.StatusReasonInternalError,
-				Message: err.Error(),
-			},
+			Allowed: true,
 		}
 	}
 
-	var hap *v1alpha1.HighAvailabilityPolicy
+	log.Printf("Validating Deployment %s:%s against %s", dpl.Namespace, dpl.Name, policy.Explain(candidates))
+	hap, el := exempt("Deployment", dpl.ObjectMeta, policy.Merge(candidates))
 
-	// go over all items and see if the selector matches this deployment
-	for _, dhap := range hapList.Items {
-		// the currently selected hap has a higher weight than this one, don't
-		// bother checking anything.
-		if hap != nil {
-			if hap.Spec.Weight > dhap.Spec.Weight {
-				continue
-			}
+	violations, err := h.deploymentViolations(dpl, hap)
+	if err != nil {
+		return internalError(err)
+	}
+
+	el = append(el, violations...)
+
+	if grandfathers(hap, old != nil, el) {
+		_, oldEl := policy.ParseExemption(old.ObjectMeta)
+		oldViolations, err := h.deploymentViolations(old, hap)
+		if err != nil {
+			return internalError(err)
 		}
 
-		shap := &dhap
-		lblSelector, err := metav1.LabelSelectorAsSelector(shap.Spec.Selector)
+		el = grandfather("Deployment", dpl.ObjectMeta, el, append(oldEl, oldViolations...), dpl.Spec.Template.Spec, old.Spec.Template.Spec)
+	}
+
+	return enforce(candidates, exceptions, "Deployment", dpl.ObjectMeta, el)
+}
+
+// deploymentViolations validates the Deployment, its autoscaler and the
+// PodDisruptionBudgets selecting it against the given policy.
+func (h *HighAvailabilityAdmissionHook) deploymentViolations(dpl *appsv1.Deployment, hap *v1alpha1.HighAvailabilityPolicy) (field.ErrorList, error) {
+	// when an autoscaler manages the replica count, the bounds of the
+	// autoscaler are validated instead of the replica count itself. Without
+	// replica bounds in the policy there's nothing to validate them against.
+	var hpa *autoscalingv1.HorizontalPodAutoscaler
+	if hap.Spec.Replicas != nil {
+		var err error
+		if hpa, err = h.Informers.autoscaler(dpl); err != nil {
+			return nil, err
+		}
+	}
+
+	var el field.ErrorList
+	if hpa != nil {
+		el = validation.ValidateAutoscaledDeployment(*dpl, *hpa, *hap)
+	} else {
+		el = validation.ValidateDeployment(*dpl, *hap)
+	}
+
+	if hap.Spec.Disruptions != nil {
+		pdbs, err := h.Informers.budgets(dpl.Namespace)
 		if err != nil {
-			log.Printf("Could not get label selector for %s:%s: %s", shap.Namespace, shap.Name, err)
-
-			return &v1beta1.AdmissionResponse{
-				Allowed: false,
-				Result: &metav1.Status{
-					Status:  metav1.StatusFailure,
-					Code:    http.StatusInternalServerError,
-					Reason:  metav1.StatusReasonInternalError,
-					Message: err.Error(),
-				},
-			}
+			return nil, err
+		}
+
+		dpls, err := h.Informers.deployments(dpl.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		el = append(el, validation.ValidateDisruptions(*dpl, pdbs, dpls, *hap)...)
+	}
+
+	return el, nil
+}
+
+func (h *HighAvailabilityAdmissionHook) validateStatefulSet(sts, old *appsv1.StatefulSet) *v1beta1.AdmissionResponse {
+	candidates, exceptions, err := h.Informers.policies.Candidates(sts.ObjectMeta)
+	if err != nil {
+		return internalError(err)
+	}
+
+	// no hap which selects this resource, ignore it!
+	if len(candidates) == 0 {
+		return &v1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	log.Printf("Validating StatefulSet %s:%s against %s", sts.Namespace, sts.Name, policy.Explain(candidates))
+	hap, el := exempt("StatefulSet", sts.ObjectMeta, policy.Merge(candidates))
+	el = append(el, validation.ValidateStatefulSet(*sts, *hap)...)
+
+	if grandfathers(hap, old != nil, el) {
+		_, oldEl := policy.ParseExemption(old.ObjectMeta)
+		el = grandfather("StatefulSet", sts.ObjectMeta, el, append(oldEl, validation.ValidateStatefulSet(*old, *hap)...), sts.Spec.Template.Spec, old.Spec.Template.Spec)
+	}
+
+	return enforce(candidates, exceptions, "StatefulSet", sts.ObjectMeta, el)
+}
+
+func (h *HighAvailabilityAdmissionHook) validateDaemonSet(ds, old *appsv1.DaemonSet) *v1beta1.AdmissionResponse {
+	candidates, exceptions, err := h.Informers.policies.Candidates(ds.ObjectMeta)
+	if err != nil {
+		return internalError(err)
+	}
+
+	// no hap which selects this resource, ignore it!
+	if len(candidates) == 0 {
+		return &v1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	log.Printf("Validating DaemonSet %s:%s against %s", ds.Namespace, ds.Name, policy.Explain(candidates))
+	hap, el := exempt("DaemonSet", ds.ObjectMeta, policy.Merge(candidates))
+	el = append(el, validation.ValidateDaemonSet(*ds, *hap)...)
+
+	if grandfathers(hap, old != nil, el) {
+		_, oldEl := policy.ParseExemption(old.ObjectMeta)
+		el = grandfather("DaemonSet", ds.ObjectMeta, el, append(oldEl, validation.ValidateDaemonSet(*old, *hap)...), ds.Spec.Template.Spec, old.Spec.Template.Spec)
+	}
+
+	return enforce(candidates, exceptions, "DaemonSet", ds.ObjectMeta, el)
+}
+
+// validateHorizontalPodAutoscaler validates the autoscaler against the policy
+// which selects the Deployment it scales. Autoscalers for other workloads or
+// for Deployments which don't exist yet are let through.
+func (h *HighAvailabilityAdmissionHook) validateHorizontalPodAutoscaler(hpa *autoscalingv1.HorizontalPodAutoscaler) *v1beta1.AdmissionResponse {
+	if hpa.Spec.ScaleTargetRef.Kind != workloads.DeploymentKind {
+		return &v1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	dpl, err := h.Informers.dplLister.Deployments(hpa.Namespace).Get(hpa.Spec.ScaleTargetRef.Name)
+	if kerrors.IsNotFound(err) {
+		return &v1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	} else if err != nil {
+		return internalError(err)
+	}
+
+	candidates, exceptions, err := h.Informers.policies.Candidates(dpl.ObjectMeta)
+	if err != nil {
+		return internalError(err)
+	}
+
+	// no hap which selects the scaled resource, ignore it!
+	if len(candidates) == 0 {
+		return &v1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	log.Printf("Validating HorizontalPodAutoscaler %s:%s against %s", hpa.Namespace, hpa.Name, policy.Explain(candidates))
+
+	// the exemptions of the Deployment apply to its autoscaler, an invalid
+	// exemption is reported when the Deployment itself is validated.
+	hap, _ := exempt("Deployment", dpl.ObjectMeta, policy.Merge(candidates))
+	return enforce(candidates, exceptions, "HorizontalPodAutoscaler", hpa.ObjectMeta, validation.ValidateHorizontalPodAutoscaler(*hpa, *hap))
+}
+
+// validatePodDisruptionBudget validates the budget against every Deployment
+// and StatefulSet it selects, using the policy which selects that workload.
+// The budget is denied when it violates any policy which denies violations.
+func (h *HighAvailabilityAdmissionHook) validatePodDisruptionBudget(pdb *policyv1beta1.PodDisruptionBudget) *v1beta1.AdmissionResponse {
+	selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	// an empty selector doesn't select any pods, there's nothing to protect.
+	if selector.Empty() {
+		return &v1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	dpls, err := h.Informers.deployments(pdb.Namespace)
+	if err != nil {
+		return internalError(err)
+	}
+
+	stss, err := h.Informers.statefulSets(pdb.Namespace)
+	if err != nil {
+		return internalError(err)
+	}
+
+	// the other budgets are needed to validate that budgets are unique.
+	pdbs, err := h.Informers.budgets(pdb.Namespace)
+	if err != nil {
+		return internalError(err)
+	}
+
+	responses := []*v1beta1.AdmissionResponse{}
+	for _, dpl := range dpls {
+		if !selector.Matches(labels.Set(dpl.Spec.Template.Labels)) {
+			continue
 		}
 
-		// the labels match and we know this hap has a higher weight than the
-		// currently selected one, mark this one to be used!
-		if lblSelector.Matches(labels.Set(dpl.Labels)) {
-			hap = shap
+		dpl := dpl
+		responses = append(responses, h.validateBudgetFor(pdb, "Deployment", dpl.ObjectMeta, func(hap *v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+			return validation.ValidateDisruptionBudget(*pdb, dpl, pdbs, dpls, *hap)
+		}))
+	}
+
+	for _, sts := range stss {
+		if !selector.Matches(labels.Set(sts.Spec.Template.Labels)) {
+			continue
+		}
+
+		sts := sts
+		responses = append(responses, h.validateBudgetFor(pdb, "StatefulSet", sts.ObjectMeta, func(hap *v1alpha1.HighAvailabilityPolicy) field.ErrorList {
+			return validation.ValidateStatefulSetDisruptionBudget(*pdb, sts, pdbs, stss, *hap)
+		}))
+	}
+
+	resp := &v1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+
+	for _, workloadResp := range responses {
+		if !workloadResp.Allowed {
+			return workloadResp
+		}
+
+		// keep the first warning, the response can only hold a single
+		// message.
+		if resp.Result == nil {
+			resp = workloadResp
 		}
 	}
 
+	return resp
+}
+
+// validateBudgetFor validates the budget against a single workload it selects
+// with the given validation, using the policy which selects that workload.
+func (h *HighAvailabilityAdmissionHook) validateBudgetFor(pdb *policyv1beta1.PodDisruptionBudget, kind string, obj metav1.ObjectMeta, validate func(*v1alpha1.HighAvailabilityPolicy) field.ErrorList) *v1beta1.AdmissionResponse {
+	candidates, exceptions, err := h.Informers.policies.Candidates(obj)
+	if err != nil {
+		return internalError(err)
+	}
+
 	// no hap which selects this resource, ignore it!
-	if hap == nil {
+	if len(candidates) == 0 {
 		return &v1beta1.AdmissionResponse{
 			Allowed: true,
 		}
 	}
 
-	log.Printf("Validating %s:%s", dpl.Namespace, dpl.Name)
-	if err := validation.ValidateDeployment(dpl, *hap).ToAggregate(); err != nil {
+	log.Printf("Validating PodDisruptionBudget %s:%s for %s %s against %s", pdb.Namespace, pdb.Name, kind, obj.Name, policy.Explain(candidates))
+
+	// the exemptions of the workload apply to the budgets selecting it, an
+	// invalid exemption is reported when the workload itself is validated.
+	hap, _ := exempt(kind, obj, policy.Merge(candidates))
+	return enforce(candidates, exceptions, "PodDisruptionBudget", pdb.ObjectMeta, validate(hap))
+}
+
+// grandfathers checks if the violations an object already had before an update
+// should be ignored, which the UpdatePolicy of the policy determines. When
+// there are no violations, there's no need to validate the old object.
+func grandfathers(hap *v1alpha1.HighAvailabilityPolicy, update bool, el field.ErrorList) bool {
+	return update && len(el) > 0 && hap.Spec.UpdatePolicy == v1alpha1.UpdatePolicyNoNewViolations
+}
+
+// grandfather only keeps the violations the object didn't have before the
+// update and logs the violations which are ignored. The pod specs of the
+// object before and after the update are used to match the violations of its
+// containers.
+func grandfather(kind string, obj metav1.ObjectMeta, el, old field.ErrorList, podSpec, oldPodSpec v1.PodSpec) field.ErrorList {
+	violations := validation.NewViolations(el, old, podSpec, oldPodSpec)
+	if ignored := len(el) - len(violations); ignored > 0 {
+		log.Printf("Ignoring %d existing violations of %s %s:%s on update", ignored, kind, obj.Namespace, obj.Name)
+	}
+
+	return violations
+}
+
+// exempt removes the sections the object is exempted from through its
+// annotations from the policy and logs the exemptions which are used. The
+// errors of an invalid exemption are returned, the policy is used as is then.
+func exempt(kind string, obj metav1.ObjectMeta, hap *v1alpha1.HighAvailabilityPolicy) (*v1alpha1.HighAvailabilityPolicy, field.ErrorList) {
+	now := time.Now()
+	exempted, exemption, el := policy.Exempt(obj, hap, now)
+
+	switch {
+	case exemption == nil:
+	case exemption.Expired(now):
+		log.Printf("Ignoring exemption of %s %s:%s from %s, it expired at %s", kind, obj.Namespace, obj.Name, exemption, exemption.Expires.Format(time.RFC3339))
+	default:
+		log.Printf("Exempting %s %s:%s from %s of %s", kind, obj.Namespace, obj.Name, exemption, policy.Describe(hap))
+	}
+
+	return exempted, el
+}
+
+// enforce creates the admission response for the validation errors of an
+// object based on the EnforcementAction of the policy it was validated against.
+// The policy is the first of the given candidates, the messages explain why it
+// was selected over the others and which of them it was merged with. The
+// exceptions which were applied are listed in the messages as well.
+func enforce(candidates []*v1alpha1.HighAvailabilityPolicy, exceptions []*v1alpha1.HighAvailabilityPolicyException, kind string, obj metav1.ObjectMeta, el field.ErrorList) *v1beta1.AdmissionResponse {
+	hap := candidates[0]
+	explanation := policy.Explain(candidates)
+
+	if len(exceptions) > 0 {
+		applied := make([]string, len(exceptions))
+		for i, exception := range exceptions {
+			applied[i] = policy.DescribeException(exception)
+		}
+
+		explanation = fmt.Sprintf("%s with %s applied", explanation, strings.Join(applied, ", "))
+		log.Printf("Applying %s to %s %s:%s", strings.Join(applied, ", "), kind, obj.Namespace, obj.Name)
+	}
+
+	err := el.ToAggregate()
+	if err == nil {
+		resp := &v1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+
+		// tell the requester which exceptions allowed the object in.
+		if len(exceptions) > 0 {
+			resp.Result = &metav1.Status{
+				Status:  metav1.StatusSuccess,
+				Message: fmt.Sprintf("%s complies with %s", kind, explanation),
+			}
+		}
+
+		return resp
+	}
+
+	switch hap.Spec.EnforcementAction {
+	case v1alpha1.EnforcementActionWarn:
+		log.Printf("Allowing %s %s:%s which violates %s: %s", kind, obj.Namespace, obj.Name, explanation, err)
+
+		// the admission API we serve doesn't support warnings, the message of
+		// an allowed response is the closest thing to it.
 		return &v1beta1.AdmissionResponse{
-			Allowed: false,
+			Allowed: true,
 			Result: &metav1.Status{
-				Status:  metav1.StatusFailure,
-				Code:    http.StatusNotAcceptable,
-				Reason:  metav1.StatusReasonNotAcceptable,
-				Message: err.Error(),
+				Status:  metav1.StatusSuccess,
+				Message: fmt.Sprintf("%s violates %s: %s", kind, explanation, err),
 			},
 		}
+	case v1alpha1.EnforcementActionDryRun:
+		log.Printf("[dryrun] %s %s:%s violates %s: %s", kind, obj.Namespace, obj.Name, explanation, err)
+		return &v1beta1.AdmissionResponse{
+			Allowed: true,
+		}
 	}
 
+	log.Printf("Denying %s %s:%s which violates %s: %s", kind, obj.Namespace, obj.Name, explanation, err)
+	return notAcceptable(fmt.Errorf("%s violates %s: %s", kind, explanation, err))
+}
+
+func badRequest(err error) *v1beta1.AdmissionResponse {
 	return &v1beta1.AdmissionResponse{
-		Allowed: true,
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    http.StatusBadRequest,
+			Reason:  metav1.StatusReasonBadRequest,
+			Message: err.Error(),
+		},
+	}
+}
+
+func notAcceptable(err error) *v1beta1.AdmissionResponse {
+	return &v1beta1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    http.StatusNotAcceptable,
+			Reason:  metav1.StatusReasonNotAcceptable,
+			Message: err.Error(),
+		},
+	}
+}
+
+func internalError(err error) *v1beta1.AdmissionResponse {
+	return &v1beta1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    http.StatusInternalServerError,
+			Reason:  metav1.StatusReasonInternalError,
+			Message: err.Error(),
+		},
 	}
 }
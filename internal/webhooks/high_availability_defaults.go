@@ -0,0 +1,115 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+	"github.com/jelmersnoeck/barbossa/internal/defaults"
+	"github.com/jelmersnoeck/barbossa/internal/policy"
+	"github.com/jelmersnoeck/barbossa/internal/workloads"
+
+	"k8s.io/api/admission/v1beta1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+// DisableDefaultsAnnotation is the annotation which can be set to "true" on a
+// Deployment to opt out of having the policy defaults applied to it.
+const DisableDefaultsAnnotation = "barbossa.sphc.io/disable-defaults"
+
+// HighAvailabilityDefaultsHook is a mutating admission hook which patches the
+// values a Deployment is missing to conform with the HighAvailabilityPolicy
+// that selects it, instead of only rejecting the Deployment.
+type HighAvailabilityDefaultsHook struct {
+	// Informers are the informers shared with the other admission hooks.
+	Informers *Informers
+}
+
+func (h *HighAvailabilityDefaultsHook) Initialize(cfg *rest.Config, stopCh <-chan struct{}) error {
+	return h.Informers.Initialize(cfg, stopCh)
+}
+
+func (h *HighAvailabilityDefaultsHook) MutatingResource() (plural schema.GroupVersionResource, singular string) {
+	gv := v1alpha1.SchemeGroupVersion
+	gv.Group = "admission." + gv.Group
+	return gv.WithResource("highavailabilitydefaults"), "highavailabilitydefault"
+}
+
+func (h *HighAvailabilityDefaultsHook) Admit(ar *v1beta1.AdmissionRequest) *v1beta1.AdmissionResponse {
+	gvk := schema.GroupVersionKind{Group: ar.Kind.Group, Version: ar.Kind.Version, Kind: ar.Kind.Kind}
+
+	// we only apply defaults to Deployments, any other resource is let
+	// through untouched.
+	if !workloads.IsDeployment(gvk) {
+		return &v1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	dpl, err := workloads.DecodeDeployment(gvk, ar.Object.Raw)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	if dpl.Annotations[DisableDefaultsAnnotation] == "true" {
+		return &v1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	hap, err := h.Informers.policies.Select(dpl.ObjectMeta)
+	if err != nil {
+		return internalError(err)
+	}
+
+	// no hap which selects this resource, ignore it!
+	if hap == nil {
+		return &v1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	// the sections the Deployment is exempted from aren't defaulted either.
+	// The exemption is logged and an invalid exemption is reported when the
+	// Deployment is validated.
+	hap, _, _ = policy.Exempt(dpl.ObjectMeta, hap, time.Now())
+
+	// the replica count of a Deployment which is scaled by an autoscaler is
+	// left to the autoscaler. Without replica bounds in the policy there's no
+	// replica count to default, so there's no need to look it up.
+	var hpa *autoscalingv1.HorizontalPodAutoscaler
+	if hap.Spec.Replicas != nil {
+		if hpa, err = h.Informers.autoscaler(dpl); err != nil {
+			return internalError(err)
+		}
+	}
+
+	var ops []defaults.PatchOperation
+	if hpa != nil {
+		ops = defaults.AutoscaledDeployment(*dpl, *hap)
+	} else {
+		ops = defaults.Deployment(*dpl, *hap)
+	}
+
+	if len(ops) == 0 {
+		return &v1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	patch, err := json.Marshal(ops)
+	if err != nil {
+		return internalError(err)
+	}
+
+	log.Printf("Applying defaults to Deployment %s:%s", dpl.Namespace, dpl.Name)
+	patchType := v1beta1.PatchTypeJSONPatch
+	return &v1beta1.AdmissionResponse{
+		Allowed:   true,
+		Patch:     patch,
+		PatchType: &patchType,
+	}
+}
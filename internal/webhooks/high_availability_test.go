@@ -0,0 +1,382 @@
+package webhooks_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jelmersnoeck/barbossa/apis/barbossa/v1alpha1"
+	"github.com/jelmersnoeck/barbossa/internal/webhooks"
+	crdfake "github.com/jelmersnoeck/barbossa/pkg/client/generated/clientset/versioned/fake"
+
+	"k8s.io/api/admission/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestValidateDeployment(t *testing.T) {
+	critical := criticalPolicy("critical", v1alpha1.EnforcementActionDeny)
+	warn := criticalPolicy("critical", v1alpha1.EnforcementActionWarn)
+	dryRun := criticalPolicy("critical", v1alpha1.EnforcementActionDryRun)
+
+	// the baseline policy selects the same Deployments with a lower weight.
+	baseline := criticalPolicy("baseline", v1alpha1.EnforcementActionDeny)
+	critical10 := critical.DeepCopy()
+	critical10.Spec.Weight = 10
+
+	noNewViolations := critical.DeepCopy()
+	noNewViolations.Spec.UpdatePolicy = v1alpha1.UpdatePolicyNoNewViolations
+
+	exempted := criticalDeployment(1, "1Gi")
+	exempted.Annotations = map[string]string{
+		v1alpha1.ExemptAnnotation:                 "replicas",
+		v1alpha1.ExemptionJustificationAnnotation: "a single replica is enough for the batch job",
+	}
+
+	expired := exempted.DeepCopy()
+	expired.Annotations[v1alpha1.ExemptionExpiresAnnotation] = "2000-01-01T00:00:00Z"
+
+	unjustified := exempted.DeepCopy()
+	delete(unjustified.Annotations, v1alpha1.ExemptionJustificationAnnotation)
+
+	updated := criticalDeployment(1, "1Gi")
+	updated.Spec.Template.Spec.Containers[0].Image = "web:v2"
+
+	tcs := map[string]struct {
+		crdObjects []runtime.Object
+		dpl        *appsv1.Deployment
+		old        *appsv1.Deployment
+		oldRaw     []byte
+		allowed    bool
+		message    string
+		excluded   string
+	}{
+		"without a policy": {
+			dpl:     criticalDeployment(1, ""),
+			allowed: true,
+		},
+		"with a compliant deployment": {
+			crdObjects: []runtime.Object{critical},
+			dpl:        criticalDeployment(2, "1Gi"),
+			allowed:    true,
+		},
+		"with a violation of a deny policy": {
+			crdObjects: []runtime.Object{critical},
+			dpl:        criticalDeployment(1, "1Gi"),
+			message:    "Deployment violates HighAvailabilityPolicy default:critical: spec.replicas",
+		},
+		"with a violation of a warn policy": {
+			crdObjects: []runtime.Object{warn},
+			dpl:        criticalDeployment(1, "1Gi"),
+			allowed:    true,
+			message:    "Deployment violates HighAvailabilityPolicy default:critical: spec.replicas",
+		},
+		"with a violation of a dryrun policy": {
+			crdObjects: []runtime.Object{dryRun},
+			dpl:        criticalDeployment(1, "1Gi"),
+			allowed:    true,
+		},
+		"with a policy selected over another": {
+			crdObjects: []runtime.Object{baseline, critical10},
+			dpl:        criticalDeployment(1, "1Gi"),
+			message:    "HighAvailabilityPolicy default:critical, selected over HighAvailabilityPolicy default:baseline (lower weight)",
+		},
+		"with an exemption": {
+			crdObjects: []runtime.Object{critical},
+			dpl:        exempted,
+			allowed:    true,
+		},
+		"with an expired exemption": {
+			crdObjects: []runtime.Object{critical},
+			dpl:        expired,
+			message:    "spec.replicas",
+		},
+		"with an exemption without a justification": {
+			crdObjects: []runtime.Object{critical},
+			dpl:        unjustified,
+			message:    v1alpha1.ExemptionJustificationAnnotation,
+		},
+		"with an exception for the selected policy": {
+			crdObjects: []runtime.Object{critical, exception("replicas", "critical")},
+			dpl:        criticalDeployment(1, "1Gi"),
+			allowed:    true,
+			message:    "Deployment complies with HighAvailabilityPolicy default:critical with HighAvailabilityPolicyException default:replicas (replicas) applied",
+		},
+		"with an exception for a policy which isn't used": {
+			crdObjects: []runtime.Object{baseline, critical10, exception("replicas", "baseline")},
+			dpl:        criticalDeployment(1, "1Gi"),
+			message:    "spec.replicas",
+			excluded:   "HighAvailabilityPolicyException",
+		},
+		"with an update keeping a violation": {
+			crdObjects: []runtime.Object{noNewViolations},
+			dpl:        updated,
+			old:        criticalDeployment(1, "1Gi"),
+			allowed:    true,
+		},
+		"with an update adding a violation": {
+			crdObjects: []runtime.Object{noNewViolations},
+			dpl:        criticalDeployment(1, ""),
+			old:        criticalDeployment(1, "1Gi"),
+			message:    "spec.template.spec.containers[0].resources.requests.memory",
+			excluded:   "spec.replicas",
+		},
+		"with an update worsening a violation": {
+			crdObjects: []runtime.Object{noNewViolations},
+			dpl:        criticalDeployment(0, "1Gi"),
+			old:        criticalDeployment(1, "1Gi"),
+			message:    "spec.replicas",
+		},
+		"with an update keeping a violation of a strict policy": {
+			crdObjects: []runtime.Object{critical},
+			dpl:        updated,
+			old:        criticalDeployment(1, "1Gi"),
+			message:    "spec.replicas",
+		},
+		"with an update of an invalid deployment": {
+			crdObjects: []runtime.Object{noNewViolations},
+			dpl:        criticalDeployment(1, "1Gi"),
+			oldRaw:     []byte("{"),
+			message:    "unexpected end of JSON input",
+		},
+	}
+
+	workloads := []runtime.Object{
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			ar := admissionRequest(t, appsv1.SchemeGroupVersion.WithKind("Deployment"), tc.dpl)
+			if tc.old != nil {
+				ar = updateRequest(t, ar, tc.old)
+			} else if tc.oldRaw != nil {
+				ar.Operation = v1beta1.Update
+				ar.OldObject.Raw = tc.oldRaw
+			}
+
+			hook := newHook(t, stopCh, workloads, tc.crdObjects)
+			resp := hook.Validate(ar)
+			expectResponse(t, resp, tc.allowed, tc.message)
+
+			if tc.excluded != "" && resp.Result != nil && strings.Contains(resp.Result.Message, tc.excluded) {
+				t.Errorf("Expected the message not to contain '%s', got '%s'", tc.excluded, resp.Result.Message)
+			}
+		})
+	}
+}
+
+func TestValidatePodDisruptionBudget(t *testing.T) {
+	hap := &v1alpha1.HighAvailabilityPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "critical"},
+		Spec: v1alpha1.HighAvailabilityPolicySpec{
+			Selector:    &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "critical"}},
+			Disruptions: &v1alpha1.HighAvailabilityPolicyDisruptions{Budgetted: true},
+		},
+	}
+
+	warn := hap.DeepCopy()
+	warn.Spec.EnforcementAction = v1alpha1.EnforcementActionWarn
+
+	workloads := []runtime.Object{
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		deployment("web", map[string]string{"tier": "critical"}, map[string]string{"app": "web"}, 2),
+		statefulSet("db", map[string]string{"tier": "critical"}, map[string]string{"app": "db"}, 3),
+		statefulSet("cache", nil, map[string]string{"app": "cache"}, 3),
+	}
+
+	tcs := map[string]struct {
+		hap     *v1alpha1.HighAvailabilityPolicy
+		pdb     *policyv1beta1.PodDisruptionBudget
+		allowed bool
+		message string
+	}{
+		"with a budget for a Deployment": {
+			hap:     hap,
+			pdb:     budget("web", "app", "web", intstr.FromInt(1)),
+			allowed: true,
+		},
+		"with a budget blocking the disruptions of a Deployment": {
+			hap:     hap,
+			pdb:     budget("web", "app", "web", intstr.FromInt(0)),
+			message: "should allow a pod of Deployment 'web' with 2 replicas to be disrupted",
+		},
+		"with a budget for a StatefulSet": {
+			hap:     hap,
+			pdb:     budget("db", "app", "db", intstr.FromInt(1)),
+			allowed: true,
+		},
+		"with a budget blocking the disruptions of a StatefulSet": {
+			hap:     hap,
+			pdb:     budget("db", "app", "db", intstr.FromInt(0)),
+			message: "should allow a pod of StatefulSet 'db' with 3 replicas to be disrupted",
+		},
+		"with a budget allowing all pods of a StatefulSet to be disrupted": {
+			hap:     hap,
+			pdb:     budget("db", "app", "db", intstr.FromString("100%")),
+			message: "should keep a pod of StatefulSet 'db' with 3 replicas available",
+		},
+		"with a budget for a StatefulSet without a policy": {
+			hap:     hap,
+			pdb:     budget("cache", "app", "cache", intstr.FromInt(0)),
+			allowed: true,
+		},
+		"with a budget violating a warning policy": {
+			hap:     warn,
+			pdb:     budget("db", "app", "db", intstr.FromInt(0)),
+			allowed: true,
+			message: "should allow a pod of StatefulSet 'db' with 3 replicas to be disrupted",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			hook := newHook(t, stopCh, workloads, []runtime.Object{tc.hap})
+			resp := hook.Validate(admissionRequest(t, policyv1beta1.SchemeGroupVersion.WithKind("PodDisruptionBudget"), tc.pdb))
+			expectResponse(t, resp, tc.allowed, tc.message)
+		})
+	}
+}
+
+func newHook(t *testing.T, stopCh <-chan struct{}, kubeObjects, crdObjects []runtime.Object) *webhooks.HighAvailabilityAdmissionHook {
+	t.Helper()
+
+	informers := &webhooks.Informers{}
+	if err := informers.InitializeWithClients(kubefake.NewSimpleClientset(kubeObjects...), crdfake.NewSimpleClientset(crdObjects...), stopCh); err != nil {
+		t.Fatalf("Could not initialize the informers: %s", err)
+	}
+
+	return &webhooks.HighAvailabilityAdmissionHook{Informers: informers}
+}
+
+func admissionRequest(t *testing.T, gvk schema.GroupVersionKind, obj runtime.Object) *v1beta1.AdmissionRequest {
+	t.Helper()
+
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("Could not encode the object: %s", err)
+	}
+
+	return &v1beta1.AdmissionRequest{
+		Kind:      metav1.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind},
+		Operation: v1beta1.Create,
+		Object:    runtime.RawExtension{Raw: raw},
+	}
+}
+
+func updateRequest(t *testing.T, ar *v1beta1.AdmissionRequest, old runtime.Object) *v1beta1.AdmissionRequest {
+	t.Helper()
+
+	raw, err := json.Marshal(old)
+	if err != nil {
+		t.Fatalf("Could not encode the old object: %s", err)
+	}
+
+	ar.Operation = v1beta1.Update
+	ar.OldObject = runtime.RawExtension{Raw: raw}
+	return ar
+}
+
+func expectResponse(t *testing.T, resp *v1beta1.AdmissionResponse, allowed bool, message string) {
+	t.Helper()
+
+	if resp.Allowed != allowed {
+		t.Errorf("Expected allowed to be %t, got %t: %v", allowed, resp.Allowed, resp.Result)
+	}
+
+	switch {
+	case message == "" && resp.Result != nil:
+		t.Errorf("Expected no message, got '%s'", resp.Result.Message)
+	case message != "" && resp.Result == nil:
+		t.Errorf("Expected a message containing '%s', got none", message)
+	case message != "" && !strings.Contains(resp.Result.Message, message):
+		t.Errorf("Expected a message containing '%s', got '%s'", message, resp.Result.Message)
+	}
+}
+
+func criticalPolicy(name string, action v1alpha1.EnforcementAction) *v1alpha1.HighAvailabilityPolicy {
+	return &v1alpha1.HighAvailabilityPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: v1alpha1.HighAvailabilityPolicySpec{
+			EnforcementAction: action,
+			Selector:          &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "critical"}},
+			Replicas:          &v1alpha1.HighAvailabilityPolicyReplicas{Minimum: 2},
+			Resources: &v1alpha1.HighAvailabilityPolicyResourceRequirements{
+				Requests: v1alpha1.ResourceList{v1.ResourceMemory: true},
+			},
+		},
+	}
+}
+
+func exception(name, policy string) *v1alpha1.HighAvailabilityPolicyException {
+	return &v1alpha1.HighAvailabilityPolicyException{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: v1alpha1.HighAvailabilityPolicyExceptionSpec{
+			Policy:   v1alpha1.HighAvailabilityPolicyReference{Name: policy},
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "critical"}},
+			Sections: []v1alpha1.Section{v1alpha1.SectionReplicas},
+			Reason:   "the replicas are scaled by an external controller",
+		},
+	}
+}
+
+// criticalDeployment returns a Deployment which is selected by the critical
+// policies, without a memory request when it's empty.
+func criticalDeployment(replicas int32, memory string) *appsv1.Deployment {
+	dpl := deployment("web", map[string]string{"tier": "critical"}, map[string]string{"app": "web"}, replicas)
+
+	container := v1.Container{Name: "web", Image: "web:v1"}
+	if memory != "" {
+		container.Resources.Requests = v1.ResourceList{v1.ResourceMemory: resource.MustParse(memory)}
+	}
+
+	dpl.Spec.Template.Spec.Containers = []v1.Container{container}
+	return dpl
+}
+
+func deployment(name string, lbls, podLabels map[string]string, replicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: lbls},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+			},
+		},
+	}
+}
+
+func statefulSet(name string, lbls, podLabels map[string]string, replicas int32) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: lbls},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+			},
+		},
+	}
+}
+
+func budget(name, key, value string, maxUnavailable intstr.IntOrString) *policyv1beta1.PodDisruptionBudget {
+	return &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{key: value}},
+			MaxUnavailable: &maxUnavailable,
+		},
+	}
+}
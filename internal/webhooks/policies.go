@@ -0,0 +1,176 @@
+package webhooks
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/jelmersnoeck/barbossa/internal/policy"
+	"github.com/jelmersnoeck/barbossa/internal/workloads"
+	"github.com/jelmersnoeck/barbossa/pkg/client/generated/clientset/versioned"
+	"github.com/jelmersnoeck/barbossa/pkg/client/generated/informers/externalversions"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	autoscalinglisters "k8s.io/client-go/listers/autoscaling/v1"
+	policylisters "k8s.io/client-go/listers/policy/v1beta1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+// resyncPeriod is the interval in which the informers resync their cache.
+const resyncPeriod = 10 * time.Minute
+
+// Informers holds the informers and policy cache the admission hooks share. A single instance should be given to all hooks so the resources are
+// only watched once. It's initialized by the first hook which is initialized.
+type Informers struct {
+	once sync.Once
+	err  error
+
+	policies  *policy.Cache
+	dplLister appslisters.DeploymentLister
+	stsLister appslisters.StatefulSetLister
+	pdbLister policylisters.PodDisruptionBudgetLister
+	hpaLister autoscalinglisters.HorizontalPodAutoscalerLister
+}
+
+// Initialize creates the clients and starts the informers for the policy
+// cache and the resources the policies are validated with, it waits for them
+// to be synced. The admission hooks are initialized before the server reports
+// itself as ready, by waiting for the cache to be synced we never validate
+// against an empty cache. Calling it again returns the result of the first
+// call.
+func (i *Informers) Initialize(cfg *rest.Config, stopCh <-chan struct{}) error {
+	i.once.Do(func() {
+		i.err = i.initialize(cfg, stopCh)
+	})
+
+	return i.err
+}
+
+// InitializeWithClients is Initialize for clients which were created before,
+// like the fake clients used in tests.
+func (i *Informers) InitializeWithClients(kubeClient kubernetes.Interface, crdClient versioned.Interface, stopCh <-chan struct{}) error {
+	i.once.Do(func() {
+		i.err = i.start(kubeClient, crdClient, stopCh)
+	})
+
+	return i.err
+}
+
+func (i *Informers) initialize(cfg *rest.Config, stopCh <-chan struct{}) error {
+	crdClient, err := versioned.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	return i.start(kubeClient, crdClient, stopCh)
+}
+
+func (i *Informers) start(kubeClient kubernetes.Interface, crdClient versioned.Interface, stopCh <-chan struct{}) error {
+	crdInformers := externalversions.NewSharedInformerFactory(crdClient, resyncPeriod)
+	kubeInformers := informers.NewSharedInformerFactory(kubeClient, resyncPeriod)
+	policies := policy.NewCache(kubeClient, crdInformers, kubeInformers)
+	dplInformer := kubeInformers.Apps().V1().Deployments()
+	dplLister := dplInformer.Lister()
+	stsInformer := kubeInformers.Apps().V1().StatefulSets()
+	stsLister := stsInformer.Lister()
+	pdbInformer := kubeInformers.Policy().V1beta1().PodDisruptionBudgets()
+	pdbLister := pdbInformer.Lister()
+	hpaInformer := kubeInformers.Autoscaling().V1().HorizontalPodAutoscalers()
+	hpaLister := hpaInformer.Lister()
+
+	// the factories only start the informers which were requested before,
+	// requesting the listers registers their informers.
+	crdInformers.Start(stopCh)
+	kubeInformers.Start(stopCh)
+
+	synced := []cache.InformerSynced{
+		policies.HasSynced,
+		dplInformer.Informer().HasSynced,
+		stsInformer.Informer().HasSynced,
+		pdbInformer.Informer().HasSynced,
+		hpaInformer.Informer().HasSynced,
+	}
+
+	if !cache.WaitForCacheSync(stopCh, synced...) {
+		return errors.New("could not sync the policy cache")
+	}
+
+	i.policies = policies
+	i.dplLister = dplLister
+	i.stsLister = stsLister
+	i.pdbLister = pdbLister
+	i.hpaLister = hpaLister
+	return nil
+}
+
+// deployments returns the Deployments in the given namespace.
+func (i *Informers) deployments(namespace string) ([]appsv1.Deployment, error) {
+	dpls, err := i.dplLister.Deployments(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]appsv1.Deployment, len(dpls))
+	for j, dpl := range dpls {
+		items[j] = *dpl
+	}
+
+	return items, nil
+}
+
+// statefulSets returns the StatefulSets in the given namespace.
+func (i *Informers) statefulSets(namespace string) ([]appsv1.StatefulSet, error) {
+	stss, err := i.stsLister.StatefulSets(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]appsv1.StatefulSet, len(stss))
+	for j, sts := range stss {
+		items[j] = *sts
+	}
+
+	return items, nil
+}
+
+// budgets returns the PodDisruptionBudgets in the given namespace.
+func (i *Informers) budgets(namespace string) ([]policyv1beta1.PodDisruptionBudget, error) {
+	pdbs, err := i.pdbLister.PodDisruptionBudgets(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]policyv1beta1.PodDisruptionBudget, len(pdbs))
+	for j, pdb := range pdbs {
+		items[j] = *pdb
+	}
+
+	return items, nil
+}
+
+// autoscaler returns the HorizontalPodAutoscaler which scales the Deployment.
+func (i *Informers) autoscaler(dpl *appsv1.Deployment) (*autoscalingv1.HorizontalPodAutoscaler, error) {
+	hpas, err := i.hpaLister.HorizontalPodAutoscalers(dpl.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]autoscalingv1.HorizontalPodAutoscaler, len(hpas))
+	for j, hpa := range hpas {
+		items[j] = *hpa
+	}
+
+	return workloads.Autoscaler(workloads.DeploymentKind, dpl.Name, items), nil
+}
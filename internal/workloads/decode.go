@@ -0,0 +1,172 @@
+// Package workloads decodes the different API versions of the workloads we
+// validate into a single internal form, their apps/v1 representation.
+// HorizontalPodAutoscalers, which manage the replica count of a workload, are
+// decoded into their autoscaling/v1 representation.
+//
+// All served versions of a workload share the same representation for the
+// fields we validate. Decoding into apps/v1 directly only drops the fields
+// which have been removed over time, like `spec.rollbackTo`.
+package workloads
+
+import (
+	"encoding/json"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// The Kinds used for the workload resources in every API group.
+const (
+	DeploymentKind  = "Deployment"
+	StatefulSetKind = "StatefulSet"
+	DaemonSetKind   = "DaemonSet"
+
+	HorizontalPodAutoscalerKind = "HorizontalPodAutoscaler"
+)
+
+// deploymentVersions are the API versions in which a Deployment is served.
+var deploymentVersions = []schema.GroupVersion{
+	{Group: "extensions", Version: "v1beta1"},
+	{Group: "apps", Version: "v1beta1"},
+	{Group: "apps", Version: "v1beta2"},
+	{Group: "apps", Version: "v1"},
+}
+
+// statefulSetVersions are the API versions in which a StatefulSet is served.
+var statefulSetVersions = []schema.GroupVersion{
+	{Group: "apps", Version: "v1beta1"},
+	{Group: "apps", Version: "v1beta2"},
+	{Group: "apps", Version: "v1"},
+}
+
+// daemonSetVersions are the API versions in which a DaemonSet is served.
+var daemonSetVersions = []schema.GroupVersion{
+	{Group: "extensions", Version: "v1beta1"},
+	{Group: "apps", Version: "v1beta2"},
+	{Group: "apps", Version: "v1"},
+}
+
+// horizontalPodAutoscalerVersions are the API versions in which a
+// HorizontalPodAutoscaler is served. The replica bounds and the scale target
+// are the same in all of them, the metrics are not.
+var horizontalPodAutoscalerVersions = []schema.GroupVersion{
+	{Group: "autoscaling", Version: "v1"},
+	{Group: "autoscaling", Version: "v2beta1"},
+}
+
+// IsDeployment checks if the given GroupVersionKind is a Deployment in one of
+// the API versions we know how to decode.
+func IsDeployment(gvk schema.GroupVersionKind) bool {
+	return gvk.Kind == DeploymentKind && servedIn(deploymentVersions, gvk.GroupVersion())
+}
+
+// IsStatefulSet checks if the given GroupVersionKind is a StatefulSet in one
+// of the API versions we know how to decode.
+func IsStatefulSet(gvk schema.GroupVersionKind) bool {
+	return gvk.Kind == StatefulSetKind && servedIn(statefulSetVersions, gvk.GroupVersion())
+}
+
+// IsDaemonSet checks if the given GroupVersionKind is a DaemonSet in one of
+// the API versions we know how to decode.
+func IsDaemonSet(gvk schema.GroupVersionKind) bool {
+	return gvk.Kind == DaemonSetKind && servedIn(daemonSetVersions, gvk.GroupVersion())
+}
+
+// DecodeDeployment decodes a Deployment of any of the API versions it's served
+// in into an apps/v1 Deployment. This is the form we validate against, so the
+// same policy applies whichever version a manifest uses.
+func DecodeDeployment(gvk schema.GroupVersionKind, raw []byte) (*appsv1.Deployment, error) {
+	if !IsDeployment(gvk) {
+		return nil, unsupportedError(gvk, DeploymentKind)
+	}
+
+	dpl := &appsv1.Deployment{}
+	if err := json.Unmarshal(raw, dpl); err != nil {
+		return nil, err
+	}
+
+	return dpl, nil
+}
+
+// DecodeStatefulSet decodes a StatefulSet of any of the API versions it's
+// served in into an apps/v1 StatefulSet.
+func DecodeStatefulSet(gvk schema.GroupVersionKind, raw []byte) (*appsv1.StatefulSet, error) {
+	if !IsStatefulSet(gvk) {
+		return nil, unsupportedError(gvk, StatefulSetKind)
+	}
+
+	sts := &appsv1.StatefulSet{}
+	if err := json.Unmarshal(raw, sts); err != nil {
+		return nil, err
+	}
+
+	return sts, nil
+}
+
+// DecodeDaemonSet decodes a DaemonSet of any of the API versions it's served
+// in into an apps/v1 DaemonSet.
+func DecodeDaemonSet(gvk schema.GroupVersionKind, raw []byte) (*appsv1.DaemonSet, error) {
+	if !IsDaemonSet(gvk) {
+		return nil, unsupportedError(gvk, DaemonSetKind)
+	}
+
+	ds := &appsv1.DaemonSet{}
+	if err := json.Unmarshal(raw, ds); err != nil {
+		return nil, err
+	}
+
+	return ds, nil
+}
+
+// IsHorizontalPodAutoscaler checks if the given GroupVersionKind is a
+// HorizontalPodAutoscaler in one of the API versions we know how to decode.
+func IsHorizontalPodAutoscaler(gvk schema.GroupVersionKind) bool {
+	return gvk.Kind == HorizontalPodAutoscalerKind && servedIn(horizontalPodAutoscalerVersions, gvk.GroupVersion())
+}
+
+// DecodeHorizontalPodAutoscaler decodes a HorizontalPodAutoscaler of any of
+// the API versions it's served in into an autoscaling/v1
+// HorizontalPodAutoscaler. Metrics which can't be expressed in autoscaling/v1
+// are dropped, we don't validate them.
+func DecodeHorizontalPodAutoscaler(gvk schema.GroupVersionKind, raw []byte) (*autoscalingv1.HorizontalPodAutoscaler, error) {
+	if !IsHorizontalPodAutoscaler(gvk) {
+		return nil, unsupportedError(gvk, HorizontalPodAutoscalerKind)
+	}
+
+	hpa := &autoscalingv1.HorizontalPodAutoscaler{}
+	if err := json.Unmarshal(raw, hpa); err != nil {
+		return nil, err
+	}
+
+	return hpa, nil
+}
+
+// Autoscaler returns the HorizontalPodAutoscaler which scales the workload of
+// the given kind and name. The autoscalers should live in the namespace of the
+// workload. When there's no autoscaler for the workload, nil is returned.
+func Autoscaler(kind, name string, hpas []autoscalingv1.HorizontalPodAutoscaler) *autoscalingv1.HorizontalPodAutoscaler {
+	for i := range hpas {
+		ref := hpas[i].Spec.ScaleTargetRef
+		if ref.Kind == kind && ref.Name == name {
+			return &hpas[i]
+		}
+	}
+
+	return nil
+}
+
+func servedIn(versions []schema.GroupVersion, gv schema.GroupVersion) bool {
+	for _, v := range versions {
+		if v == gv {
+			return true
+		}
+	}
+
+	return false
+}
+
+func unsupportedError(gvk schema.GroupVersionKind, kind string) error {
+	return fmt.Errorf("can not decode %s as a %s", gvk, kind)
+}
@@ -0,0 +1,113 @@
+package workloads_test
+
+import (
+	"testing"
+
+	"github.com/jelmersnoeck/barbossa/internal/workloads"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const deploymentJSON = `{
+	"metadata": {"name": "web", "namespace": "default"},
+	"spec": {
+		"replicas": 3,
+		"strategy": {
+			"type": "RollingUpdate",
+			"rollingUpdate": {"maxSurge": "50%", "maxUnavailable": 0}
+		}
+	}
+}`
+
+func TestDecodeDeployment(t *testing.T) {
+	for _, gv := range []string{"extensions/v1beta1", "apps/v1beta1", "apps/v1beta2", "apps/v1"} {
+		t.Run(gv, func(t *testing.T) {
+			parsed, err := schema.ParseGroupVersion(gv)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dpl, err := workloads.DecodeDeployment(parsed.WithKind("Deployment"), []byte(deploymentJSON))
+			if err != nil {
+				t.Fatalf("Expected no error, got '%s'", err)
+			}
+
+			if dpl.Name != "web" {
+				t.Errorf("Expected name to be 'web', got '%s'", dpl.Name)
+			}
+
+			if dpl.Spec.Replicas == nil || *dpl.Spec.Replicas != 3 {
+				t.Errorf("Expected replicas to be 3, got '%v'", dpl.Spec.Replicas)
+			}
+
+			if dpl.Spec.Strategy.Type != appsv1.RollingUpdateDeploymentStrategyType {
+				t.Errorf("Expected strategy to be '%s', got '%s'", appsv1.RollingUpdateDeploymentStrategyType, dpl.Spec.Strategy.Type)
+			}
+
+			if dpl.Spec.Strategy.RollingUpdate.MaxSurge.String() != "50%" {
+				t.Errorf("Expected maxSurge to be '50%%', got '%s'", dpl.Spec.Strategy.RollingUpdate.MaxSurge.String())
+			}
+		})
+	}
+
+	t.Run("with an unsupported kind", func(t *testing.T) {
+		gvk := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "ReplicaSet"}
+		if _, err := workloads.DecodeDeployment(gvk, []byte(deploymentJSON)); err == nil {
+			t.Errorf("Expected an error for kind '%s'", gvk)
+		}
+	})
+}
+
+const horizontalPodAutoscalerJSON = `{
+	"metadata": {"name": "web", "namespace": "default"},
+	"spec": {
+		"scaleTargetRef": {"apiVersion": "apps/v1", "kind": "Deployment", "name": "web"},
+		"minReplicas": 2,
+		"maxReplicas": 10
+	}
+}`
+
+func TestDecodeHorizontalPodAutoscaler(t *testing.T) {
+	for _, gv := range []string{"autoscaling/v1", "autoscaling/v2beta1"} {
+		t.Run(gv, func(t *testing.T) {
+			parsed, err := schema.ParseGroupVersion(gv)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			hpa, err := workloads.DecodeHorizontalPodAutoscaler(parsed.WithKind("HorizontalPodAutoscaler"), []byte(horizontalPodAutoscalerJSON))
+			if err != nil {
+				t.Fatalf("Expected no error, got '%s'", err)
+			}
+
+			if hpa.Spec.MinReplicas == nil || *hpa.Spec.MinReplicas != 2 {
+				t.Errorf("Expected minReplicas to be 2, got '%v'", hpa.Spec.MinReplicas)
+			}
+
+			if hpa.Spec.MaxReplicas != 10 {
+				t.Errorf("Expected maxReplicas to be 10, got '%d'", hpa.Spec.MaxReplicas)
+			}
+		})
+	}
+}
+
+func TestAutoscaler(t *testing.T) {
+	hpas := []autoscalingv1.HorizontalPodAutoscaler{
+		{Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{Kind: "StatefulSet", Name: "web"},
+		}},
+		{Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{Kind: "Deployment", Name: "web"},
+		}},
+	}
+
+	if hpa := workloads.Autoscaler(workloads.DeploymentKind, "web", hpas); hpa != &hpas[1] {
+		t.Errorf("Expected the autoscaler for Deployment 'web', got '%v'", hpa)
+	}
+
+	if hpa := workloads.Autoscaler(workloads.DeploymentKind, "api", hpas); hpa != nil {
+		t.Errorf("Expected no autoscaler for Deployment 'api', got '%v'", hpa)
+	}
+}
@@ -0,0 +1,164 @@
+package workloads
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// The API versions of which the defaults differ from the apps/v1 defaults.
+var (
+	extensionsV1beta1 = schema.GroupVersion{Group: "extensions", Version: "v1beta1"}
+	appsV1beta1       = schema.GroupVersion{Group: "apps", Version: "v1beta1"}
+)
+
+// The values the API server defaults in apps/v1 when they're not set.
+const (
+	defaultReplicas                = 1
+	defaultRevisionHistoryLimit    = 10
+	defaultProgressDeadlineSeconds = 600
+)
+
+// apps/v1beta1 keeps a shorter revision history of a Deployment.
+const appsV1beta1DeploymentRevisionHistoryLimit = 2
+
+// The rolling update values the API server defaults in apps/v1.
+var (
+	defaultDeploymentMaxSurge       = intstr.FromString("25%")
+	defaultDeploymentMaxUnavailable = intstr.FromString("25%")
+	defaultDaemonSetMaxUnavailable  = intstr.FromInt(1)
+)
+
+// The rolling update values the API server defaults for a Deployment in
+// extensions/v1beta1.
+var (
+	extensionsDeploymentMaxSurge       = intstr.FromInt(1)
+	extensionsDeploymentMaxUnavailable = intstr.FromInt(1)
+)
+
+// DefaultDeployment sets the values the API server defaults for a Deployment
+// of the given API version which we validate. Workloads which are read from
+// manifests haven't been defaulted yet, unlike the ones an admission hook
+// receives. The defaults of the API version the manifest uses apply, even
+// though the Deployment was decoded into its apps/v1 representation.
+func DefaultDeployment(gv schema.GroupVersion, dpl *appsv1.Deployment) {
+	spec := &dpl.Spec
+	extensions := gv == extensionsV1beta1
+
+	if spec.Replicas == nil {
+		spec.Replicas = int32Ptr(defaultReplicas)
+	}
+
+	if spec.Strategy.Type == "" {
+		spec.Strategy.Type = appsv1.RollingUpdateDeploymentStrategyType
+	}
+
+	// extensions/v1beta1 also defaults a rolling update configuration which
+	// is set for another strategy type.
+	if spec.Strategy.Type == appsv1.RollingUpdateDeploymentStrategyType || (extensions && spec.Strategy.RollingUpdate != nil) {
+		if spec.Strategy.RollingUpdate == nil {
+			spec.Strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{}
+		}
+
+		maxUnavailable, maxSurge := defaultDeploymentMaxUnavailable, defaultDeploymentMaxSurge
+		if extensions {
+			maxUnavailable, maxSurge = extensionsDeploymentMaxUnavailable, extensionsDeploymentMaxSurge
+		}
+
+		if spec.Strategy.RollingUpdate.MaxUnavailable == nil {
+			spec.Strategy.RollingUpdate.MaxUnavailable = &maxUnavailable
+		}
+
+		if spec.Strategy.RollingUpdate.MaxSurge == nil {
+			spec.Strategy.RollingUpdate.MaxSurge = &maxSurge
+		}
+	}
+
+	// extensions/v1beta1 keeps the full revision history and doesn't limit
+	// the progress of a rollout.
+	if extensions {
+		return
+	}
+
+	if spec.RevisionHistoryLimit == nil {
+		revisionHistoryLimit := int32(defaultRevisionHistoryLimit)
+		if gv == appsV1beta1 {
+			revisionHistoryLimit = appsV1beta1DeploymentRevisionHistoryLimit
+		}
+
+		spec.RevisionHistoryLimit = &revisionHistoryLimit
+	}
+
+	if spec.ProgressDeadlineSeconds == nil {
+		spec.ProgressDeadlineSeconds = int32Ptr(defaultProgressDeadlineSeconds)
+	}
+}
+
+// DefaultStatefulSet sets the values the API server defaults for a
+// StatefulSet of the given API version which we validate.
+func DefaultStatefulSet(gv schema.GroupVersion, sts *appsv1.StatefulSet) {
+	spec := &sts.Spec
+
+	if spec.Replicas == nil {
+		spec.Replicas = int32Ptr(defaultReplicas)
+	}
+
+	if spec.PodManagementPolicy == "" {
+		spec.PodManagementPolicy = appsv1.OrderedReadyPodManagement
+	}
+
+	// apps/v1beta1 doesn't update the pods of a StatefulSet unless they're
+	// deleted.
+	if spec.UpdateStrategy.Type == "" && gv == appsV1beta1 {
+		spec.UpdateStrategy.Type = appsv1.OnDeleteStatefulSetStrategyType
+	}
+
+	if spec.UpdateStrategy.Type == "" {
+		spec.UpdateStrategy.Type = appsv1.RollingUpdateStatefulSetStrategyType
+		spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{}
+	}
+
+	rollingUpdate := spec.UpdateStrategy.RollingUpdate
+	if spec.UpdateStrategy.Type == appsv1.RollingUpdateStatefulSetStrategyType && rollingUpdate != nil && rollingUpdate.Partition == nil {
+		rollingUpdate.Partition = int32Ptr(0)
+	}
+
+	if spec.RevisionHistoryLimit == nil {
+		spec.RevisionHistoryLimit = int32Ptr(defaultRevisionHistoryLimit)
+	}
+}
+
+// DefaultDaemonSet sets the values the API server defaults for a DaemonSet of
+// the given API version which we validate.
+func DefaultDaemonSet(gv schema.GroupVersion, ds *appsv1.DaemonSet) {
+	spec := &ds.Spec
+
+	// extensions/v1beta1 doesn't update the pods of a DaemonSet unless
+	// they're deleted.
+	if spec.UpdateStrategy.Type == "" && gv == extensionsV1beta1 {
+		spec.UpdateStrategy.Type = appsv1.OnDeleteDaemonSetStrategyType
+	}
+
+	if spec.UpdateStrategy.Type == "" {
+		spec.UpdateStrategy.Type = appsv1.RollingUpdateDaemonSetStrategyType
+	}
+
+	if spec.UpdateStrategy.Type == appsv1.RollingUpdateDaemonSetStrategyType {
+		if spec.UpdateStrategy.RollingUpdate == nil {
+			spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateDaemonSet{}
+		}
+
+		if spec.UpdateStrategy.RollingUpdate.MaxUnavailable == nil {
+			maxUnavailable := defaultDaemonSetMaxUnavailable
+			spec.UpdateStrategy.RollingUpdate.MaxUnavailable = &maxUnavailable
+		}
+	}
+
+	if spec.RevisionHistoryLimit == nil {
+		spec.RevisionHistoryLimit = int32Ptr(defaultRevisionHistoryLimit)
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
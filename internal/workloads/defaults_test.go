@@ -0,0 +1,165 @@
+package workloads_test
+
+import (
+	"testing"
+
+	"github.com/jelmersnoeck/barbossa/internal/workloads"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var (
+	extensionsV1beta1 = schema.GroupVersion{Group: "extensions", Version: "v1beta1"}
+	appsV1beta1       = schema.GroupVersion{Group: "apps", Version: "v1beta1"}
+)
+
+func TestDefaultDeployment(t *testing.T) {
+	t.Run("without a strategy", func(t *testing.T) {
+		dpl := &appsv1.Deployment{}
+		workloads.DefaultDeployment(appsv1.SchemeGroupVersion, dpl)
+
+		if dpl.Spec.Replicas == nil || *dpl.Spec.Replicas != 1 {
+			t.Errorf("Expected the replica count to default to 1, got %v", dpl.Spec.Replicas)
+		}
+
+		strategy := dpl.Spec.Strategy
+		if strategy.Type != appsv1.RollingUpdateDeploymentStrategyType || strategy.RollingUpdate == nil {
+			t.Fatalf("Expected the strategy to default to a rolling update, got %v", strategy)
+		}
+
+		if strategy.RollingUpdate.MaxSurge.String() != "25%" || strategy.RollingUpdate.MaxUnavailable.String() != "25%" {
+			t.Errorf("Expected the rolling update to default to 25%%, got %s and %s", strategy.RollingUpdate.MaxSurge.String(), strategy.RollingUpdate.MaxUnavailable.String())
+		}
+
+		if *dpl.Spec.RevisionHistoryLimit != 10 || *dpl.Spec.ProgressDeadlineSeconds != 600 {
+			t.Errorf("Expected the rollout values to be defaulted, got %d and %d", *dpl.Spec.RevisionHistoryLimit, *dpl.Spec.ProgressDeadlineSeconds)
+		}
+	})
+
+	t.Run("with a Recreate strategy", func(t *testing.T) {
+		dpl := &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			},
+		}
+		workloads.DefaultDeployment(appsv1.SchemeGroupVersion, dpl)
+
+		if dpl.Spec.Strategy.RollingUpdate != nil {
+			t.Errorf("Expected no rolling update for a Recreate strategy")
+		}
+	})
+
+	t.Run("with values set", func(t *testing.T) {
+		maxSurge := intstr.FromInt(0)
+		dpl := &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Strategy: appsv1.DeploymentStrategy{
+					RollingUpdate: &appsv1.RollingUpdateDeployment{MaxSurge: &maxSurge},
+				},
+			},
+		}
+		workloads.DefaultDeployment(appsv1.SchemeGroupVersion, dpl)
+
+		if dpl.Spec.Strategy.RollingUpdate.MaxSurge.String() != "0" {
+			t.Errorf("Expected the max surge to be kept, got %s", dpl.Spec.Strategy.RollingUpdate.MaxSurge.String())
+		}
+	})
+
+	t.Run("in extensions/v1beta1", func(t *testing.T) {
+		dpl := &appsv1.Deployment{}
+		workloads.DefaultDeployment(extensionsV1beta1, dpl)
+
+		rollingUpdate := dpl.Spec.Strategy.RollingUpdate
+		if rollingUpdate == nil {
+			t.Fatalf("Expected the strategy to default to a rolling update")
+		}
+
+		if rollingUpdate.MaxSurge.String() != "1" || rollingUpdate.MaxUnavailable.String() != "1" {
+			t.Errorf("Expected the rolling update to default to 1, got %s and %s", rollingUpdate.MaxSurge.String(), rollingUpdate.MaxUnavailable.String())
+		}
+
+		if dpl.Spec.RevisionHistoryLimit != nil || dpl.Spec.ProgressDeadlineSeconds != nil {
+			t.Errorf("Expected the revision history and the progress not to be limited, got %v and %v", dpl.Spec.RevisionHistoryLimit, dpl.Spec.ProgressDeadlineSeconds)
+		}
+	})
+
+	t.Run("in apps/v1beta1", func(t *testing.T) {
+		dpl := &appsv1.Deployment{}
+		workloads.DefaultDeployment(appsV1beta1, dpl)
+
+		if *dpl.Spec.RevisionHistoryLimit != 2 {
+			t.Errorf("Expected the revision history limit to default to 2, got %d", *dpl.Spec.RevisionHistoryLimit)
+		}
+	})
+}
+
+func TestDefaultStatefulSet(t *testing.T) {
+	t.Run("without a strategy", func(t *testing.T) {
+		sts := &appsv1.StatefulSet{}
+		workloads.DefaultStatefulSet(appsv1.SchemeGroupVersion, sts)
+
+		if sts.Spec.PodManagementPolicy != appsv1.OrderedReadyPodManagement {
+			t.Errorf("Expected the pod management policy to default to %s, got %s", appsv1.OrderedReadyPodManagement, sts.Spec.PodManagementPolicy)
+		}
+
+		strategy := sts.Spec.UpdateStrategy
+		if strategy.Type != appsv1.RollingUpdateStatefulSetStrategyType || strategy.RollingUpdate == nil || *strategy.RollingUpdate.Partition != 0 {
+			t.Errorf("Expected the strategy to default to a rolling update without a partition, got %v", strategy)
+		}
+	})
+
+	t.Run("with a RollingUpdate strategy", func(t *testing.T) {
+		sts := &appsv1.StatefulSet{
+			Spec: appsv1.StatefulSetSpec{
+				UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType},
+			},
+		}
+		workloads.DefaultStatefulSet(appsv1.SchemeGroupVersion, sts)
+
+		if sts.Spec.UpdateStrategy.RollingUpdate != nil {
+			t.Errorf("Expected no rolling update configuration to be added, got %v", sts.Spec.UpdateStrategy.RollingUpdate)
+		}
+	})
+
+	t.Run("in apps/v1beta1", func(t *testing.T) {
+		sts := &appsv1.StatefulSet{}
+		workloads.DefaultStatefulSet(appsV1beta1, sts)
+
+		strategy := sts.Spec.UpdateStrategy
+		if strategy.Type != appsv1.OnDeleteStatefulSetStrategyType || strategy.RollingUpdate != nil {
+			t.Errorf("Expected the strategy to default to %s, got %v", appsv1.OnDeleteStatefulSetStrategyType, strategy)
+		}
+	})
+}
+
+func TestDefaultDaemonSet(t *testing.T) {
+	t.Run("without a strategy", func(t *testing.T) {
+		ds := &appsv1.DaemonSet{}
+		workloads.DefaultDaemonSet(appsv1.SchemeGroupVersion, ds)
+
+		strategy := ds.Spec.UpdateStrategy
+		if strategy.Type != appsv1.RollingUpdateDaemonSetStrategyType || strategy.RollingUpdate == nil {
+			t.Fatalf("Expected the strategy to default to a rolling update, got %v", strategy)
+		}
+
+		if strategy.RollingUpdate.MaxUnavailable.String() != "1" {
+			t.Errorf("Expected the max unavailable to default to 1, got %s", strategy.RollingUpdate.MaxUnavailable.String())
+		}
+	})
+
+	t.Run("in extensions/v1beta1", func(t *testing.T) {
+		ds := &appsv1.DaemonSet{}
+		workloads.DefaultDaemonSet(extensionsV1beta1, ds)
+
+		strategy := ds.Spec.UpdateStrategy
+		if strategy.Type != appsv1.OnDeleteDaemonSetStrategyType || strategy.RollingUpdate != nil {
+			t.Errorf("Expected the strategy to default to %s, got %v", appsv1.OnDeleteDaemonSetStrategyType, strategy)
+		}
+
+		if *ds.Spec.RevisionHistoryLimit != 10 {
+			t.Errorf("Expected the revision history limit to default to 10, got %d", *ds.Spec.RevisionHistoryLimit)
+		}
+	})
+}
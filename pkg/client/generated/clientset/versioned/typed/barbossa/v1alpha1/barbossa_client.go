@@ -33,7 +33,9 @@ import (
 
 type BarbossaV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	ClusterHighAvailabilityPoliciesGetter
 	HighAvailabilityPoliciesGetter
+	HighAvailabilityPolicyExceptionsGetter
 }
 
 // BarbossaV1alpha1Client is used to interact with features provided by the barbossa.sphc.io group.
@@ -41,10 +43,18 @@ type BarbossaV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *BarbossaV1alpha1Client) ClusterHighAvailabilityPolicies() ClusterHighAvailabilityPolicyInterface {
+	return newClusterHighAvailabilityPolicies(c)
+}
+
 func (c *BarbossaV1alpha1Client) HighAvailabilityPolicies(namespace string) HighAvailabilityPolicyInterface {
 	return newHighAvailabilityPolicies(c, namespace)
 }
 
+func (c *BarbossaV1alpha1Client) HighAvailabilityPolicyExceptions(namespace string) HighAvailabilityPolicyExceptionInterface {
+	return newHighAvailabilityPolicyExceptions(c, namespace)
+}
+
 // NewForConfig creates a new BarbossaV1alpha1Client for the given config.
 func NewForConfig(c *rest.Config) (*BarbossaV1alpha1Client, error) {
 	config := *c
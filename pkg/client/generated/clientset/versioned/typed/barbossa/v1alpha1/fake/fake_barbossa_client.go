@@ -34,10 +34,18 @@ type FakeBarbossaV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeBarbossaV1alpha1) ClusterHighAvailabilityPolicies() v1alpha1.ClusterHighAvailabilityPolicyInterface {
+	return &FakeClusterHighAvailabilityPolicies{c}
+}
+
 func (c *FakeBarbossaV1alpha1) HighAvailabilityPolicies(namespace string) v1alpha1.HighAvailabilityPolicyInterface {
 	return &FakeHighAvailabilityPolicies{c, namespace}
 }
 
+func (c *FakeBarbossaV1alpha1) HighAvailabilityPolicyExceptions(namespace string) v1alpha1.HighAvailabilityPolicyExceptionInterface {
+	return &FakeHighAvailabilityPolicyExceptions{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeBarbossaV1alpha1) RESTClient() rest.Interface {
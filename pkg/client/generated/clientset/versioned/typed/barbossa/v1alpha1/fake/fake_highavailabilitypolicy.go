@@ -106,6 +106,18 @@ func (c *FakeHighAvailabilityPolicies) Update(highAvailabilityPolicy *v1alpha1.H
 	return obj.(*v1alpha1.HighAvailabilityPolicy), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeHighAvailabilityPolicies) UpdateStatus(highAvailabilityPolicy *v1alpha1.HighAvailabilityPolicy) (*v1alpha1.HighAvailabilityPolicy, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(highavailabilitypoliciesResource, "status", c.ns, highAvailabilityPolicy), &v1alpha1.HighAvailabilityPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.HighAvailabilityPolicy), err
+}
+
 // Delete takes name of the highAvailabilityPolicy and deletes it. Returns an error if one occurs.
 func (c *FakeHighAvailabilityPolicies) Delete(name string, options *v1.DeleteOptions) error {
 	_, err := c.Fake.
@@ -24,4 +24,8 @@
 
 package v1alpha1
 
+type ClusterHighAvailabilityPolicyExpansion interface{}
+
 type HighAvailabilityPolicyExpansion interface{}
+
+type HighAvailabilityPolicyExceptionExpansion interface{}
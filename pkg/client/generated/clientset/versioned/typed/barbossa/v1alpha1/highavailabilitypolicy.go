@@ -43,6 +43,7 @@ type HighAvailabilityPoliciesGetter interface {
 type HighAvailabilityPolicyInterface interface {
 	Create(*v1alpha1.HighAvailabilityPolicy) (*v1alpha1.HighAvailabilityPolicy, error)
 	Update(*v1alpha1.HighAvailabilityPolicy) (*v1alpha1.HighAvailabilityPolicy, error)
+	UpdateStatus(*v1alpha1.HighAvailabilityPolicy) (*v1alpha1.HighAvailabilityPolicy, error)
 	Delete(name string, options *v1.DeleteOptions) error
 	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
 	Get(name string, options v1.GetOptions) (*v1alpha1.HighAvailabilityPolicy, error)
@@ -126,6 +127,22 @@ func (c *highAvailabilityPolicies) Update(highAvailabilityPolicy *v1alpha1.HighA
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *highAvailabilityPolicies) UpdateStatus(highAvailabilityPolicy *v1alpha1.HighAvailabilityPolicy) (result *v1alpha1.HighAvailabilityPolicy, err error) {
+	result = &v1alpha1.HighAvailabilityPolicy{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("highavailabilitypolicies").
+		Name(highAvailabilityPolicy.Name).
+		SubResource("status").
+		Body(highAvailabilityPolicy).
+		Do().
+		Into(result)
+	return
+}
+
 // Delete takes name of the highAvailabilityPolicy and deletes it. Returns an error if one occurs.
 func (c *highAvailabilityPolicies) Delete(name string, options *v1.DeleteOptions) error {
 	return c.client.Delete().
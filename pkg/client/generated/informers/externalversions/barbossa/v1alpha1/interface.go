@@ -30,8 +30,12 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// ClusterHighAvailabilityPolicies returns a ClusterHighAvailabilityPolicyInformer.
+	ClusterHighAvailabilityPolicies() ClusterHighAvailabilityPolicyInformer
 	// HighAvailabilityPolicies returns a HighAvailabilityPolicyInformer.
 	HighAvailabilityPolicies() HighAvailabilityPolicyInformer
+	// HighAvailabilityPolicyExceptions returns a HighAvailabilityPolicyExceptionInformer.
+	HighAvailabilityPolicyExceptions() HighAvailabilityPolicyExceptionInformer
 }
 
 type version struct {
@@ -45,7 +49,17 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// ClusterHighAvailabilityPolicies returns a ClusterHighAvailabilityPolicyInformer.
+func (v *version) ClusterHighAvailabilityPolicies() ClusterHighAvailabilityPolicyInformer {
+	return &clusterHighAvailabilityPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // HighAvailabilityPolicies returns a HighAvailabilityPolicyInformer.
 func (v *version) HighAvailabilityPolicies() HighAvailabilityPolicyInformer {
 	return &highAvailabilityPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// HighAvailabilityPolicyExceptions returns a HighAvailabilityPolicyExceptionInformer.
+func (v *version) HighAvailabilityPolicyExceptions() HighAvailabilityPolicyExceptionInformer {
+	return &highAvailabilityPolicyExceptionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
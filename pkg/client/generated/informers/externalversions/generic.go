@@ -59,8 +59,12 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=barbossa.sphc.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("clusterhighavailabilitypolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Barbossa().V1alpha1().ClusterHighAvailabilityPolicies().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("highavailabilitypolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Barbossa().V1alpha1().HighAvailabilityPolicies().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("highavailabilitypolicyexceptions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Barbossa().V1alpha1().HighAvailabilityPolicyExceptions().Informer()}, nil
 
 	}
 
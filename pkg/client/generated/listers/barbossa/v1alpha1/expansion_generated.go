@@ -24,6 +24,18 @@
 
 package v1alpha1
 
+// ClusterHighAvailabilityPolicyListerExpansion allows custom methods to be added to
+// ClusterHighAvailabilityPolicyLister.
+type ClusterHighAvailabilityPolicyListerExpansion interface{}
+
+// HighAvailabilityPolicyExceptionListerExpansion allows custom methods to be added to
+// HighAvailabilityPolicyExceptionLister.
+type HighAvailabilityPolicyExceptionListerExpansion interface{}
+
+// HighAvailabilityPolicyExceptionNamespaceListerExpansion allows custom methods to be added to
+// HighAvailabilityPolicyExceptionNamespaceLister.
+type HighAvailabilityPolicyExceptionNamespaceListerExpansion interface{}
+
 // HighAvailabilityPolicyListerExpansion allows custom methods to be added to
 // HighAvailabilityPolicyLister.
 type HighAvailabilityPolicyListerExpansion interface{}